@@ -0,0 +1,79 @@
+package mouser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// bomMatchFields are the part numbers a BOM line may give: usually the
+// manufacturer's, but a Mouser part number is accepted too.
+var bomMatchFields = []partNumberField{manufacturerPartNumber, mouserPartNumber}
+
+// InsertBOM resolves each manufacturer part number in items to a Mouser part
+// number and inserts the resolved lines, with their quantities, into a new
+// cart. Part numbers are looked up with exact part number searches of up to
+// MaxPartNumbers at a time, several batches concurrently; the searches wait
+// for the client's rate limiter rather than failing when it is exhausted.
+//
+// If some lines cannot be resolved, the cart is still created from the rest
+// and returned together with an *UnresolvedPartsError listing the missing
+// part numbers. If none resolve, only the error is returned. Quantities must
+// be positive.
+// With WithStrictCartLines, a *CartLineErrorsError for lines Mouser
+// rejected is joined with any *UnresolvedPartsError and returned together
+// with the cart, as is a *CartInsertChunkError from a chunked insert.
+func (s *CartService) InsertBOM(ctx context.Context, items map[string]int, countryCode, currencyCode string) (*CartResponse, error) {
+	c := s.client
+
+	mpns := make([]string, 0, len(items))
+	for mpn, qty := range items {
+		if strings.TrimSpace(mpn) == "" {
+			return nil, fmt.Errorf("%w: BOM has an empty part number", ErrInvalidRequest)
+		}
+		if qty <= 0 {
+			return nil, fmt.Errorf("%w: BOM line %s has non-positive quantity %d", ErrInvalidRequest, mpn, qty)
+		}
+		mpns = append(mpns, mpn)
+	}
+	if len(mpns) == 0 {
+		return nil, fmt.Errorf("%w: BOM is empty", ErrInvalidRequest)
+	}
+	sort.Strings(mpns)
+
+	resolved, searchErr := c.Search.resolvePartNumbers(withRateLimitWait(ctx), mpns, bomMatchFields, mouserPartNumber)
+
+	var body CartItemRequestBody
+	var unresolved []string
+	for _, mpn := range mpns {
+		mouserPN, ok := resolved[mpn]
+		if !ok {
+			unresolved = append(unresolved, mpn)
+			continue
+		}
+		body.CartItems = append(body.CartItems, CartItemRequest{
+			MouserPartNumber: mouserPN,
+			Quantity:         items[mpn],
+		})
+	}
+
+	var unresolvedErr error
+	if len(unresolved) > 0 {
+		unresolvedErr = &UnresolvedPartsError{PartNumbers: unresolved, Err: searchErr}
+	}
+	if len(body.CartItems) == 0 {
+		return nil, unresolvedErr
+	}
+
+	resp, err := s.InsertItems(ctx, body, countryCode, currencyCode)
+	if err != nil {
+		// Line errors and failed later chunks come with the cart.
+		if resp != nil {
+			return resp, errors.Join(err, unresolvedErr)
+		}
+		return nil, err
+	}
+	return resp, unresolvedErr
+}
@@ -0,0 +1,152 @@
+package mouser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// bomHandler serves exact part number searches for known MPNs and echoes
+// cart inserts. It records the size of each search batch and the insert body.
+func bomHandler(t *testing.T, known map[string]string, batches *[]int, inserted *CartItemRequestBody) http.Handler {
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/search/partnumber":
+			var req partNumberSearchRequest
+			if err := json.Unmarshal(body, &req); err != nil {
+				t.Errorf("failed to parse search request: %v", err)
+				return
+			}
+			if req.SearchByPartRequest.PartSearchOptions != string(PartSearchOptionExact) {
+				t.Errorf("expected Exact part search, got %q", req.SearchByPartRequest.PartSearchOptions)
+			}
+			pns := strings.Split(req.SearchByPartRequest.MouserPartNumber, "|")
+
+			mu.Lock()
+			*batches = append(*batches, len(pns))
+			mu.Unlock()
+
+			var parts []Part
+			for _, pn := range pns {
+				if mouserPN, ok := known[pn]; ok {
+					parts = append(parts, Part{MouserPartNumber: mouserPN, ManufacturerPartNumber: pn})
+				}
+			}
+			_ = json.NewEncoder(w).Encode(searchResponse{
+				SearchResults: SearchResult{NumberOfResult: len(parts), Parts: parts},
+			})
+		case "/cart/items/insert":
+			if err := json.Unmarshal(body, inserted); err != nil {
+				t.Errorf("failed to parse insert request: %v", err)
+				return
+			}
+			resp := CartResponse{CartKey: "bom-cart", TotalItemCount: len(inserted.CartItems)}
+			for _, item := range inserted.CartItems {
+				resp.CartItems = append(resp.CartItems, CartOrderLine{MouserPartNumber: item.MouserPartNumber, Quantity: item.Quantity})
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+}
+
+// TestInsertBOMMock tests resolving a BOM in batches and inserting the resolved lines.
+func TestInsertBOMMock(t *testing.T) {
+	known := make(map[string]string)
+	bom := make(map[string]int)
+	for i := 0; i < 12; i++ {
+		mpn := fmt.Sprintf("MPN-%02d", i)
+		known[mpn] = "595-" + mpn
+		bom[mpn] = i + 1
+	}
+	bom["UNKNOWN-1"] = 5
+
+	var batches []int
+	var inserted CartItemRequestBody
+	client := newTestClient(t, bomHandler(t, known, &batches, &inserted))
+
+	resp, err := client.Cart.InsertBOM(context.Background(), bom, "US", "USD")
+
+	var unresolved *UnresolvedPartsError
+	if !errors.As(err, &unresolved) {
+		t.Fatalf("expected *UnresolvedPartsError, got %v", err)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected unresolved error to match ErrNotFound")
+	}
+	if len(unresolved.PartNumbers) != 1 || unresolved.PartNumbers[0] != "UNKNOWN-1" {
+		t.Errorf("expected UNKNOWN-1 unresolved, got %v", unresolved.PartNumbers)
+	}
+
+	if resp == nil || resp.CartKey != "bom-cart" {
+		t.Fatalf("expected cart response despite unresolved lines, got %+v", resp)
+	}
+	if len(batches) != 2 || batches[0]+batches[1] != 13 {
+		t.Errorf("expected 13 part numbers in 2 batches, got %v", batches)
+	}
+	for _, size := range batches {
+		if size > MaxPartNumbers {
+			t.Errorf("batch of %d exceeds MaxPartNumbers", size)
+		}
+	}
+
+	if len(inserted.CartItems) != 12 {
+		t.Fatalf("expected 12 inserted lines, got %d", len(inserted.CartItems))
+	}
+	for _, item := range inserted.CartItems {
+		mpn := strings.TrimPrefix(item.MouserPartNumber, "595-")
+		if item.Quantity != bom[mpn] {
+			t.Errorf("%s: expected quantity %d, got %d", item.MouserPartNumber, bom[mpn], item.Quantity)
+		}
+	}
+}
+
+// TestInsertBOMNothingResolvedMock tests that no cart is created when no line resolves.
+func TestInsertBOMNothingResolvedMock(t *testing.T) {
+	var batches []int
+	var inserted CartItemRequestBody
+	client := newTestClient(t, bomHandler(t, nil, &batches, &inserted))
+
+	resp, err := client.Cart.InsertBOM(context.Background(), map[string]int{"NOPE-1": 1, "NOPE-2": 2}, "", "")
+	if resp != nil {
+		t.Errorf("expected nil response, got %+v", resp)
+	}
+	var unresolved *UnresolvedPartsError
+	if !errors.As(err, &unresolved) || len(unresolved.PartNumbers) != 2 {
+		t.Fatalf("expected both lines unresolved, got %v", err)
+	}
+	if len(inserted.CartItems) != 0 {
+		t.Errorf("expected no cart insert, got %+v", inserted)
+	}
+}
+
+// TestInsertBOMValidation tests that invalid BOM lines are rejected before searching.
+func TestInsertBOMValidation(t *testing.T) {
+	client, err := NewClient("test-key")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	testCases := map[string]map[string]int{
+		"empty":         {},
+		"empty part":    {"": 1},
+		"zero quantity": {"MPN-1": 0},
+	}
+	for name, bom := range testCases {
+		if _, err := client.Cart.InsertBOM(context.Background(), bom, "", ""); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: expected ErrInvalidRequest, got %v", name, err)
+		}
+	}
+}
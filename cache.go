@@ -1,9 +1,11 @@
 package mouser
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"sync"
 	"time"
 )
@@ -21,10 +23,12 @@ type MemoryCache struct {
 	entries map[string]*cacheEntry
 	ttl     time.Duration
 	done    chan struct{}
+	clock   Clock
 }
 
 type cacheEntry struct {
 	value     []byte
+	storedAt  time.Time
 	expiresAt time.Time
 }
 
@@ -34,11 +38,33 @@ func NewMemoryCache(defaultTTL time.Duration) *MemoryCache {
 		entries: make(map[string]*cacheEntry),
 		ttl:     defaultTTL,
 		done:    make(chan struct{}),
+		clock:   realClock{},
 	}
 	go c.cleanupLoop()
 	return c
 }
 
+// NewMemoryCacheNoSweep creates an in-memory cache that does not start the
+// background cleanup goroutine. Expired entries are never returned by Get, but
+// they are only reclaimed when their key is overwritten or deleted, or when
+// Clear is called. This suits short-lived processes and tests where a
+// forgotten Close would otherwise leak the goroutine.
+func NewMemoryCacheNoSweep(defaultTTL time.Duration) *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]*cacheEntry),
+		ttl:     defaultTTL,
+		clock:   realClock{},
+	}
+}
+
+// setClock makes c tell time with clock. The background sweep still runs
+// on real time, but expiry is always judged by the clock.
+func (c *MemoryCache) setClock(clock Clock) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clock = clockOrReal(clock)
+}
+
 // Get retrieves a value from the cache.
 func (c *MemoryCache) Get(key string) ([]byte, bool) {
 	c.mu.RLock()
@@ -49,7 +75,7 @@ func (c *MemoryCache) Get(key string) ([]byte, bool) {
 		return nil, false
 	}
 
-	if time.Now().After(entry.expiresAt) {
+	if c.clock.Now().After(entry.expiresAt) {
 		return nil, false
 	}
 
@@ -66,12 +92,33 @@ func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	now := c.clock.Now()
 	c.entries[key] = &cacheEntry{
 		value:     value,
-		expiresAt: time.Now().Add(ttl),
+		storedAt:  now,
+		expiresAt: now.Add(ttl),
 	}
 }
 
+// Age returns how long ago the value for key was stored.
+// It returns false if the key is missing or expired.
+func (c *MemoryCache) Age(key string) (time.Duration, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return 0, false
+	}
+
+	now := c.clock.Now()
+	if now.After(entry.expiresAt) {
+		return 0, false
+	}
+
+	return now.Sub(entry.storedAt), true
+}
+
 // Delete removes a value from the cache.
 func (c *MemoryCache) Delete(key string) {
 	c.mu.Lock()
@@ -96,7 +143,9 @@ func (c *MemoryCache) cleanupLoop() {
 
 // Close stops the cleanup goroutine and releases resources.
 func (c *MemoryCache) Close() error {
-	close(c.done)
+	if c.done != nil {
+		close(c.done)
+	}
 	return nil
 }
 
@@ -104,7 +153,7 @@ func (c *MemoryCache) cleanup() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	now := time.Now()
+	now := c.clock.Now()
 	for key, entry := range c.entries {
 		if now.After(entry.expiresAt) {
 			delete(c.entries, key)
@@ -134,6 +183,14 @@ type CacheConfig struct {
 	ManufacturersTTL time.Duration // TTL for manufacturer list (longer, mostly static)
 	CurrenciesTTL    time.Duration // TTL for currencies list (reference data)
 	CountriesTTL     time.Duration // TTL for countries list (reference data)
+
+	// LowStockThreshold enables LowStockTTL for search results and part
+	// details containing a part with fewer than this many units in stock.
+	// Zero disables it.
+	LowStockThreshold int
+	// LowStockTTL is the TTL for low-stock results, so near-empty parts are
+	// re-checked sooner. It only shortens SearchTTL and DetailsTTL.
+	LowStockTTL time.Duration
 }
 
 // DefaultCacheConfig returns the default cache configuration.
@@ -148,6 +205,45 @@ func DefaultCacheConfig() CacheConfig {
 	}
 }
 
+// cacheSchemaVersion identifies the layout of cached values. Bump it when a
+// cached model such as Part or SearchResult changes incompatibly, so that a
+// shared cache populated by an older library version is refetched instead of
+// decoded into the wrong shape.
+const cacheSchemaVersion = 1
+
+// cacheValueHeader prefixes every value the client caches.
+var cacheValueHeader = []byte(fmt.Sprintf("mouser-cache/v%d\n", cacheSchemaVersion))
+
+// encodeCacheValue prepends the schema version header to data.
+func encodeCacheValue(data []byte) []byte {
+	value := make([]byte, 0, len(cacheValueHeader)+len(data))
+	value = append(value, cacheValueHeader...)
+	return append(value, data...)
+}
+
+// decodeCacheValue strips the schema version header. It returns false if the
+// value was written by a different version, or without a header.
+func decodeCacheValue(value []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(value, cacheValueHeader) {
+		return nil, false
+	}
+	return value[len(cacheValueHeader):], true
+}
+
+// ttlForParts returns ttl, shortened to LowStockTTL if any of parts is low
+// on stock. Parts whose stock cannot be parsed are not considered low.
+func (cfg CacheConfig) ttlForParts(ttl time.Duration, parts ...Part) time.Duration {
+	if cfg.LowStockThreshold <= 0 || cfg.LowStockTTL <= 0 || cfg.LowStockTTL >= ttl {
+		return ttl
+	}
+	for _, part := range parts {
+		if stock, ok := part.inStockQuantity(); ok && stock < cfg.LowStockThreshold {
+			return cfg.LowStockTTL
+		}
+	}
+	return ttl
+}
+
 // cacheKeyForSearch generates a cache key for a search request.
 func cacheKeyForSearch(method string, req interface{}) string {
 	data, _ := json.Marshal(req)
@@ -160,6 +256,13 @@ func cacheKeyForDetails(partNumber string) string {
 	return "details:" + partNumber
 }
 
+// cacheKeyForProductDetail generates a cache key for ProductDetail lookups.
+// It differs from the details keys, which PartDetailsWithManufacturer
+// builds from arbitrary manufacturer names.
+func cacheKeyForProductDetail(mouserPartNumber string) string {
+	return "productdetail:" + mouserPartNumber
+}
+
 // cacheKeyForManufacturers generates a cache key for the manufacturer list.
 func cacheKeyForManufacturers() string {
 	return "manufacturers:list"
@@ -1,6 +1,11 @@
 package mouser
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -277,6 +282,10 @@ func TestCacheKeyForDetails(t *testing.T) {
 	if !contains(key1, "ABC123") {
 		t.Errorf("expected cache key to contain part number, got %s", key1)
 	}
+
+	if cacheKeyForProductDetail("ABC123") == cacheKeyForDetails("mouser:ABC123") {
+		t.Error("expected ProductDetail keys not to collide with manufacturer \"mouser\" details keys")
+	}
 }
 
 // TestCacheKeyForManufacturers tests cache key generation for manufacturers.
@@ -348,6 +357,168 @@ func TestMemoryCacheCleanup(t *testing.T) {
 	}
 }
 
+// TestMemoryCacheNoSweep tests that the no-sweep cache starts no goroutine
+// and still hides expired entries.
+func TestMemoryCacheNoSweep(t *testing.T) {
+	before := runtime.NumGoroutine()
+	cache := NewMemoryCacheNoSweep(5 * time.Minute)
+	defer cache.Close()
+
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected no new goroutines, had %d now %d", before, after)
+	}
+	if cache.done != nil {
+		t.Error("expected no cleanup channel for a no-sweep cache")
+	}
+
+	cache.Set("short-lived", []byte("value"), 50*time.Millisecond)
+	cache.Set("long-lived", []byte("value"), time.Minute)
+
+	time.Sleep(100 * time.Millisecond)
+
+	if _, ok := cache.Get("short-lived"); ok {
+		t.Error("expected expired entry to be hidden")
+	}
+	if _, ok := cache.Get("long-lived"); !ok {
+		t.Error("expected unexpired entry to be returned")
+	}
+
+	// Expired entries stay in memory until overwritten, deleted, or cleared.
+	if cache.Size() != 2 {
+		t.Errorf("expected expired entry to remain stored, got size %d", cache.Size())
+	}
+}
+
+// TestMemoryCacheAge tests that entry age is tracked and increases over time.
+func TestMemoryCacheAge(t *testing.T) {
+	cache := NewMemoryCacheNoSweep(5 * time.Minute)
+
+	if _, ok := cache.Age("missing"); ok {
+		t.Error("expected no age for missing key")
+	}
+
+	cache.Set("key", []byte("value"), time.Minute)
+	first, ok := cache.Age("key")
+	if !ok {
+		t.Fatal("expected age for stored key")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	second, ok := cache.Age("key")
+	if !ok {
+		t.Fatal("expected age for stored key")
+	}
+	if second <= first || second < 20*time.Millisecond {
+		t.Errorf("expected age to increase, got %v then %v", first, second)
+	}
+
+	cache.Set("expired", []byte("value"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Age("expired"); ok {
+		t.Error("expected no age for expired key")
+	}
+}
+
+// TestCacheConfigTTLForParts tests that low-stock parts shorten the TTL.
+func TestCacheConfigTTLForParts(t *testing.T) {
+	cfg := DefaultCacheConfig()
+	cfg.LowStockThreshold = 100
+	cfg.LowStockTTL = 30 * time.Second
+
+	testCases := []struct {
+		name  string
+		parts []Part
+		want  time.Duration
+	}{
+		{"high stock", []Part{{AvailabilityInStock: "5,000"}}, cfg.SearchTTL},
+		{"low stock", []Part{{AvailabilityInStock: "5000"}, {AvailabilityInStock: "12"}}, cfg.LowStockTTL},
+		{"out of stock", []Part{{AvailabilityInStock: "0"}}, cfg.LowStockTTL},
+		{"availability message", []Part{{Availability: "7 In Stock"}}, cfg.LowStockTTL},
+		{"unknown stock", []Part{{Availability: "Non-Stocked"}}, cfg.SearchTTL},
+		{"no parts", nil, cfg.SearchTTL},
+	}
+
+	for _, tc := range testCases {
+		if got := cfg.ttlForParts(cfg.SearchTTL, tc.parts...); got != tc.want {
+			t.Errorf("%s: expected TTL %v, got %v", tc.name, tc.want, got)
+		}
+	}
+
+	// Disabled by default, and never lengthens a TTL.
+	if got := DefaultCacheConfig().ttlForParts(time.Minute, Part{AvailabilityInStock: "1"}); got != time.Minute {
+		t.Errorf("expected default config to keep TTL, got %v", got)
+	}
+	cfg.LowStockTTL = time.Hour
+	if got := cfg.ttlForParts(time.Minute, Part{AvailabilityInStock: "1"}); got != time.Minute {
+		t.Errorf("expected LowStockTTL not to lengthen TTL, got %v", got)
+	}
+}
+
+// ttlRecordingCache records the TTL of each Set.
+type ttlRecordingCache struct {
+	*MemoryCache
+	ttls map[string]time.Duration
+}
+
+func (c *ttlRecordingCache) Set(key string, value []byte, ttl time.Duration) {
+	c.ttls[key] = ttl
+	c.MemoryCache.Set(key, value, ttl)
+}
+
+// TestLowStockCacheTTLMock tests that low-stock part details are cached for
+// less time than high-stock ones.
+func TestLowStockCacheTTLMock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req partNumberSearchRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		stock := "25000"
+		if req.SearchByPartRequest.MouserPartNumber == "LOW-1" {
+			stock = "3"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(searchResponse{SearchResults: SearchResult{
+			NumberOfResult: 1,
+			Parts:          []Part{{MouserPartNumber: req.SearchByPartRequest.MouserPartNumber, AvailabilityInStock: stock}},
+		}})
+	}))
+	defer server.Close()
+
+	cfg := DefaultCacheConfig()
+	cfg.LowStockThreshold = 50
+	cfg.LowStockTTL = 30 * time.Second
+
+	cache := &ttlRecordingCache{MemoryCache: NewMemoryCacheNoSweep(cfg.DetailsTTL), ttls: make(map[string]time.Duration)}
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithCache(cache),
+		WithCacheConfig(cfg),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	for _, pn := range []string{"LOW-1", "HIGH-1"} {
+		if _, err := client.Search.PartDetails(context.Background(), pn); err != nil {
+			t.Fatalf("PartDetails(%s): %v", pn, err)
+		}
+	}
+
+	low := cache.ttls[cacheKeyForDetails("LOW-1")]
+	high := cache.ttls[cacheKeyForDetails("HIGH-1")]
+	if low != cfg.LowStockTTL {
+		t.Errorf("expected low-stock TTL %v, got %v", cfg.LowStockTTL, low)
+	}
+	if high != cfg.DetailsTTL {
+		t.Errorf("expected high-stock TTL %v, got %v", cfg.DetailsTTL, high)
+	}
+	if low >= high {
+		t.Errorf("expected low-stock TTL %v to be shorter than %v", low, high)
+	}
+}
+
 // TestMemoryCacheConcurrentAccess tests concurrent reads and writes.
 func TestMemoryCacheConcurrentAccess(t *testing.T) {
 	cache := NewMemoryCache(5 * time.Minute)
@@ -2,7 +2,11 @@ package mouser
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
+	"sort"
+	"strings"
 )
 
 // Get retrieves the contents of a cart.
@@ -30,7 +34,9 @@ func (s *CartService) Get(ctx context.Context, cartKey, countryCode, currencyCod
 	return &resp, nil
 }
 
-// Update updates an existing cart with the provided items.
+// Update updates an existing cart with the provided items. With
+// WithStrictCartLines, line item errors are returned as a
+// *CartLineErrorsError alongside the cart.
 func (s *CartService) Update(ctx context.Context, body CartItemRequestBody, countryCode, currencyCode string) (*CartResponse, error) {
 	c := s.client
 
@@ -51,13 +57,92 @@ func (s *CartService) Update(ctx context.Context, body CartItemRequestBody, coun
 		return nil, APIErrors(resp.Errors)
 	}
 
-	return &resp, nil
+	return c.checkCartLines(&resp)
 }
 
 // InsertItems inserts new items into a cart.
+//
+// If the client was created with WithCartInsertChunkSize and body has more
+// items than the chunk size, the items are inserted in sequential requests.
+// Later chunks use the cart key returned by the first, and the response of
+// the last request, which reflects the whole cart, is returned. If a chunk
+// after the first fails, the cart as of the last successful chunk is
+// returned with a *CartInsertChunkError, so the partially filled cart can be
+// recovered.
+//
+// Items are checked with CartItemRequest.Validate before anything is sent.
+// With WithStrictCartLines, line item errors are returned as a
+// *CartLineErrorsError alongside the cart.
 func (s *CartService) InsertItems(ctx context.Context, body CartItemRequestBody, countryCode, currencyCode string) (*CartResponse, error) {
 	c := s.client
 
+	if err := validateCartItems(body.CartItems, false); err != nil {
+		return nil, err
+	}
+
+	size := c.cartInsertChunkSize
+	if size <= 0 || len(body.CartItems) <= size {
+		resp, err := s.insertItems(ctx, body, countryCode, currencyCode)
+		if err != nil {
+			return nil, err
+		}
+		return c.checkCartLines(resp)
+	}
+
+	items := body.CartItems
+	chunks := (len(items) + size - 1) / size
+	var resp *CartResponse
+	for i := 0; i < chunks; i++ {
+		end := min((i+1)*size, len(items))
+		body.CartItems = items[i*size : end]
+
+		next, err := s.insertItems(ctx, body, countryCode, currencyCode)
+		if err != nil {
+			if i == 0 {
+				return nil, err
+			}
+			return resp, &CartInsertChunkError{CartKey: body.CartKey, Chunk: i + 1, Chunks: chunks, Err: err}
+		}
+		resp = next
+		body.CartKey = resp.CartKey
+	}
+
+	return c.checkCartLines(resp)
+}
+
+// isCartLineErrors reports whether err is a *CartLineErrorsError, which is
+// returned together with the updated cart.
+func isCartLineErrors(err error) bool {
+	var lineErr *CartLineErrorsError
+	return errors.As(err, &lineErr)
+}
+
+// isUpdateRejected reports whether err means Mouser answered a cart update
+// but refused it, as opposed to the request not getting through: Mouser
+// domain errors, a 4xx response, or line errors from WithStrictCartLines.
+func isUpdateRejected(err error) bool {
+	var apiErrs APIErrors
+	if errors.As(err, &apiErrs) || errors.Is(err, ErrInvalidRequest) || isCartLineErrors(err) {
+		return true
+	}
+	var mErr *MouserError
+	return errors.As(err, &mErr) && mErr.StatusCode >= 400 && mErr.StatusCode < 500
+}
+
+// checkCartLines returns resp, and with WithStrictCartLines a
+// *CartLineErrorsError if any of its lines carry errors. The cart is
+// returned either way, since the rest of the operation succeeded.
+func (c *Client) checkCartLines(resp *CartResponse) (*CartResponse, error) {
+	if c.strictCartLines && resp.HasLineErrors() {
+		return resp, &CartLineErrorsError{CartKey: resp.CartKey, LineErrors: resp.LineErrors()}
+	}
+	return resp, nil
+}
+
+// insertItems performs a single cart insert request.
+func (s *CartService) insertItems(ctx context.Context, body CartItemRequestBody, countryCode, currencyCode string) (*CartResponse, error) {
+	c := s.client
+
 	query := url.Values{}
 	if countryCode != "" {
 		query.Set("countryCode", countryCode)
@@ -78,10 +163,86 @@ func (s *CartService) InsertItems(ctx context.Context, body CartItemRequestBody,
 	return &resp, nil
 }
 
-// UpdateItems updates existing items in a cart.
+// QuantityAdjustment records a cart item quantity that InsertItemsRounded
+// raised to a legal order quantity.
+type QuantityAdjustment struct {
+	// MouserPartNumber is the adjusted part.
+	MouserPartNumber string
+
+	// Requested is the quantity asked for.
+	Requested int
+
+	// Adjusted is the quantity inserted.
+	Adjusted int
+
+	// Min is the part's minimum order quantity, as Mouser reports it.
+	Min string
+
+	// Mult is the part's order multiple, as Mouser reports it.
+	Mult string
+}
+
+// InsertItemsRounded is InsertItems with each quantity first rounded up to
+// a legal order quantity (see Part.LegalQuantity), so that Mouser does not
+// reject items below the minimum or off the order multiple. The parts are
+// looked up with cached exact part number searches of up to MaxPartNumbers
+// at a time. Items whose part is not found are inserted unchanged. The
+// returned adjustments list every item whose quantity was changed, in
+// request order. If the lookup fails, nothing is inserted.
+func (s *CartService) InsertItemsRounded(ctx context.Context, body CartItemRequestBody, countryCode, currencyCode string) (*CartResponse, []QuantityAdjustment, error) {
+	if err := validateCartItems(body.CartItems, false); err != nil {
+		return nil, nil, err
+	}
+
+	seen := make(map[string]bool, len(body.CartItems))
+	var partNumbers []string
+	for _, item := range body.CartItems {
+		if pn := item.MouserPartNumber; pn != "" && !seen[pn] {
+			seen[pn] = true
+			partNumbers = append(partNumbers, pn)
+		}
+	}
+	sort.Strings(partNumbers)
+
+	parts, err := s.client.Search.resolveParts(withRateLimitWait(ctx), partNumbers, []partNumberField{mouserPartNumber}, mouserPartNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]CartItemRequest, len(body.CartItems))
+	var adjustments []QuantityAdjustment
+	for i, item := range body.CartItems {
+		if part, ok := parts[item.MouserPartNumber]; ok {
+			if qty := part.LegalQuantity(item.Quantity); qty != item.Quantity {
+				adjustments = append(adjustments, QuantityAdjustment{
+					MouserPartNumber: item.MouserPartNumber,
+					Requested:        item.Quantity,
+					Adjusted:         qty,
+					Min:              part.Min,
+					Mult:             part.Mult,
+				})
+				item.Quantity = qty
+			}
+		}
+		items[i] = item
+	}
+	body.CartItems = items
+
+	cart, err := s.InsertItems(ctx, body, countryCode, currencyCode)
+	return cart, adjustments, err
+}
+
+// UpdateItems updates existing items in a cart. Items are checked with
+// CartItemRequest.Validate before anything is sent, except that a quantity
+// of zero is allowed to remove a line. With WithStrictCartLines, line item
+// errors are returned as a *CartLineErrorsError alongside the cart.
 func (s *CartService) UpdateItems(ctx context.Context, body CartItemRequestBody, countryCode, currencyCode string) (*CartResponse, error) {
 	c := s.client
 
+	if err := validateCartItems(body.CartItems, true); err != nil {
+		return nil, err
+	}
+
 	query := url.Values{}
 	if countryCode != "" {
 		query.Set("countryCode", countryCode)
@@ -99,7 +260,7 @@ func (s *CartService) UpdateItems(ctx context.Context, body CartItemRequestBody,
 		return nil, APIErrors(resp.Errors)
 	}
 
-	return &resp, nil
+	return c.checkCartLines(&resp)
 }
 
 // RemoveItem removes an item from the cart.
@@ -128,10 +289,77 @@ func (s *CartService) RemoveItem(ctx context.Context, cartKey, mouserPartNumber,
 	return &resp, nil
 }
 
-// InsertSchedule inserts scheduled releases for cart items.
+// RemoveItems removes several parts from a cart and returns the resulting
+// cart. It first sets the parts' quantities to zero in a single UpdateItems
+// call, which costs one request; any part still in the cart afterwards, or
+// every part if Mouser rejects that update with domain errors or a 4xx
+// status, is then removed with RemoveItem one at a time, waiting for the
+// rate limiter between calls. Duplicate part numbers are removed once.
+//
+// If the update does not reach Mouser, its error is returned unchanged. If
+// some removals fail, the cart as last returned by Mouser is returned
+// together with the joined errors.
+func (s *CartService) RemoveItems(ctx context.Context, cartKey string, partNumbers []string, countryCode, currencyCode string) (*CartResponse, error) {
+	if strings.TrimSpace(cartKey) == "" {
+		return nil, fmt.Errorf("%w: cart key is required", ErrInvalidRequest)
+	}
+
+	seen := make(map[string]bool, len(partNumbers))
+	body := CartItemRequestBody{CartKey: cartKey}
+	for _, pn := range partNumbers {
+		if strings.TrimSpace(pn) == "" {
+			return nil, fmt.Errorf("%w: empty part number", ErrInvalidRequest)
+		}
+		if !seen[pn] {
+			seen[pn] = true
+			body.CartItems = append(body.CartItems, CartItemRequest{MouserPartNumber: pn, Quantity: 0})
+		}
+	}
+	if len(body.CartItems) == 0 {
+		return nil, fmt.Errorf("%w: no part numbers to remove", ErrInvalidRequest)
+	}
+
+	cart, err := s.UpdateItems(ctx, body, countryCode, currencyCode)
+	if err != nil && !isUpdateRejected(err) {
+		return nil, err
+	}
+
+	remaining := body.CartItems
+	if cart != nil {
+		remaining = remaining[:0:0]
+		for _, line := range cart.CartItems {
+			if seen[line.MouserPartNumber] {
+				remaining = append(remaining, CartItemRequest{MouserPartNumber: line.MouserPartNumber})
+			}
+		}
+	}
+
+	ctx = withRateLimitWait(ctx)
+	var errs []error
+	for _, item := range remaining {
+		resp, err := s.RemoveItem(ctx, cartKey, item.MouserPartNumber, countryCode, currencyCode)
+		if resp != nil {
+			cart = resp
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("removing %s: %w", item.MouserPartNumber, err))
+		}
+	}
+	if len(errs) > 0 {
+		return cart, errors.Join(errs...)
+	}
+	return cart, nil
+}
+
+// InsertSchedule inserts scheduled releases for cart items. The body is
+// checked with ScheduleCartItemsRequestBody.Validate before it is sent.
 func (s *CartService) InsertSchedule(ctx context.Context, body ScheduleCartItemsRequestBody) (*CartResponse, error) {
 	c := s.client
 
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+
 	var resp CartResponse
 	if err := c.doRequest(ctx, "POST", "/cart/insert/schedule", body, &resp); err != nil {
 		return nil, err
@@ -144,10 +372,15 @@ func (s *CartService) InsertSchedule(ctx context.Context, body ScheduleCartItems
 	return &resp, nil
 }
 
-// UpdateSchedule updates scheduled releases for cart items.
+// UpdateSchedule updates scheduled releases for cart items. The body is
+// checked with ScheduleCartItemsRequestBody.Validate before it is sent.
 func (s *CartService) UpdateSchedule(ctx context.Context, body ScheduleCartItemsRequestBody) (*CartResponse, error) {
 	c := s.client
 
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+
 	var resp CartResponse
 	if err := c.doRequest(ctx, "POST", "/cart/update/schedule", body, &resp); err != nil {
 		return nil, err
@@ -178,3 +411,44 @@ func (s *CartService) DeleteAllSchedules(ctx context.Context, cartKey string) (*
 
 	return &resp, nil
 }
+
+// Enrich fetches the full catalog Part (datasheet, attributes, compliance)
+// for every line of cart, keyed by Mouser part number. Parts are looked up
+// with cached exact part number searches of up to MaxPartNumbers at a time,
+// waiting for the rate limiter rather than failing when it is exhausted.
+//
+// Lines whose part could not be found are absent from the map and listed in
+// an *UnresolvedPartsError, together with any search errors, returned
+// alongside the partial result.
+func (s *CartService) Enrich(ctx context.Context, cart *CartResponse) (map[string]*Part, error) {
+	if cart == nil {
+		return nil, fmt.Errorf("%w: nil cart", ErrInvalidRequest)
+	}
+
+	seen := make(map[string]bool, len(cart.CartItems))
+	var partNumbers []string
+	for _, line := range cart.CartItems {
+		if pn := line.MouserPartNumber; pn != "" && !seen[pn] {
+			seen[pn] = true
+			partNumbers = append(partNumbers, pn)
+		}
+	}
+	sort.Strings(partNumbers)
+
+	found, searchErr := s.client.Search.resolveParts(withRateLimitWait(ctx), partNumbers, []partNumberField{mouserPartNumber}, mouserPartNumber)
+
+	parts := make(map[string]*Part, len(found))
+	var unresolved []string
+	for _, pn := range partNumbers {
+		part, ok := found[pn]
+		if !ok {
+			unresolved = append(unresolved, pn)
+			continue
+		}
+		parts[pn] = &part
+	}
+	if len(unresolved) > 0 {
+		return parts, &UnresolvedPartsError{PartNumbers: unresolved, Err: searchErr}
+	}
+	return parts, nil
+}
@@ -1,5 +1,13 @@
 package mouser
 
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
 // PackagingChoiceType defines the packaging choice for a cart item.
 type PackagingChoiceType string
 
@@ -25,6 +33,67 @@ type CartItemRequest struct {
 	PackagingChoice PackagingChoiceType `json:"PackagingChoice,omitempty"`
 }
 
+// CartItemOption configures a CartItemRequest built by NewCartItem.
+type CartItemOption func(*CartItemRequest)
+
+// CartItemPackaging sets the packaging choice of a cart item.
+func CartItemPackaging(choice PackagingChoiceType) CartItemOption {
+	return func(r *CartItemRequest) {
+		r.PackagingChoice = choice
+	}
+}
+
+// CartItemCustomerPartNumber sets the customer part number of a cart item.
+func CartItemCustomerPartNumber(customerPartNumber string) CartItemOption {
+	return func(r *CartItemRequest) {
+		r.CustomerPartNumber = customerPartNumber
+	}
+}
+
+// NewCartItem builds a CartItemRequest for quantity units of partNumber.
+// Call Validate to check the result before sending it.
+func NewCartItem(partNumber string, quantity int, opts ...CartItemOption) CartItemRequest {
+	r := CartItemRequest{MouserPartNumber: partNumber, Quantity: quantity}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
+}
+
+// Validate checks that the item has a part number, a positive quantity, and
+// either no packaging choice or one of the PackagingChoice constants. It
+// returns an error wrapping ErrInvalidRequest.
+func (r CartItemRequest) Validate() error {
+	return r.validate(false)
+}
+
+// validate is Validate, optionally accepting a zero quantity, which
+// UpdateItems uses to remove a line.
+func (r CartItemRequest) validate(allowZero bool) error {
+	if strings.TrimSpace(r.MouserPartNumber) == "" {
+		return fmt.Errorf("%w: cart item has an empty part number", ErrInvalidRequest)
+	}
+	if r.Quantity < 0 || (r.Quantity == 0 && !allowZero) {
+		return fmt.Errorf("%w: %s has non-positive quantity %d", ErrInvalidRequest, r.MouserPartNumber, r.Quantity)
+	}
+	switch r.PackagingChoice {
+	case "", PackagingChoiceNone, PackagingChoiceCutTape, PackagingChoiceMouseReel, PackagingChoiceFullReel:
+	default:
+		return fmt.Errorf("%w: %s has invalid packaging choice %q", ErrInvalidRequest, r.MouserPartNumber, r.PackagingChoice)
+	}
+	return nil
+}
+
+// validateCartItems validates each item, naming the first invalid one.
+func validateCartItems(items []CartItemRequest, allowZero bool) error {
+	for i, item := range items {
+		if err := item.validate(allowZero); err != nil {
+			return fmt.Errorf("cart item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
 // CartItemRequestBody is the request body for cart insert/update operations.
 type CartItemRequestBody struct {
 	// CartKey is the unique cart identifier (UUID).
@@ -153,9 +222,209 @@ type ScheduleReleaseRequest struct {
 
 // ScheduleRelease represents a scheduled release with a date and quantity.
 type ScheduleRelease struct {
-	// Key is the scheduled release date.
+	// Key is the scheduled release date (YYYY-MM-DD).
 	Key string `json:"Key"`
 
 	// Value is the quantity for this release.
 	Value int `json:"Value"`
 }
+
+// ScheduleDateFormat is the layout of ScheduleRelease.Key dates.
+const ScheduleDateFormat = "2006-01-02"
+
+// Validate checks every part schedule in the body with
+// ScheduleReleaseRequest.Validate. It returns an error wrapping
+// ErrInvalidRequest.
+func (b ScheduleCartItemsRequestBody) Validate() error {
+	if len(b.ScheduleCartItems) == 0 {
+		return fmt.Errorf("%w: no schedule items", ErrInvalidRequest)
+	}
+	for _, item := range b.ScheduleCartItems {
+		if err := item.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks that the schedule has a part number and at least one
+// release, and that every release has a positive quantity and a distinct
+// ScheduleDateFormat date after today (UTC). It returns an error wrapping
+// ErrInvalidRequest.
+func (r ScheduleReleaseRequest) Validate() error {
+	pn := r.MouserPartNumber
+	if strings.TrimSpace(pn) == "" {
+		return fmt.Errorf("%w: schedule has an empty part number", ErrInvalidRequest)
+	}
+	if len(r.ScheduledReleases) == 0 {
+		return fmt.Errorf("%w: %s has no scheduled releases", ErrInvalidRequest, pn)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	seen := make(map[time.Time]bool, len(r.ScheduledReleases))
+	for _, release := range r.ScheduledReleases {
+		d, err := time.Parse(ScheduleDateFormat, release.Key)
+		if err != nil {
+			return fmt.Errorf("%w: %s has invalid release date %q", ErrInvalidRequest, pn, release.Key)
+		}
+		if !d.After(today) {
+			return fmt.Errorf("%w: %s has release date %s that is not in the future", ErrInvalidRequest, pn, release.Key)
+		}
+		if seen[d] {
+			return fmt.Errorf("%w: %s has more than one release on %s", ErrInvalidRequest, pn, release.Key)
+		}
+		seen[d] = true
+		if release.Value <= 0 {
+			return fmt.Errorf("%w: %s has non-positive quantity %d on %s", ErrInvalidRequest, pn, release.Value, release.Key)
+		}
+	}
+	return nil
+}
+
+// TotalQuantity returns the sum of the scheduled release quantities, which
+// should match the part's quantity in the cart.
+func (r ScheduleReleaseRequest) TotalQuantity() int {
+	total := 0
+	for _, release := range r.ScheduledReleases {
+		total += release.Value
+	}
+	return total
+}
+
+// SortReleases orders the releases chronologically. Releases whose dates do
+// not parse keep their relative order after the valid ones.
+func (r *ScheduleReleaseRequest) SortReleases() {
+	sort.SliceStable(r.ScheduledReleases, func(i, j int) bool {
+		di, erri := time.Parse(ScheduleDateFormat, r.ScheduledReleases[i].Key)
+		dj, errj := time.Parse(ScheduleDateFormat, r.ScheduledReleases[j].Key)
+		if erri != nil || errj != nil {
+			return erri == nil && errj != nil
+		}
+		return di.Before(dj)
+	})
+}
+
+// BuildSchedule builds a ScheduleCartItemsRequestBody from a delivery plan
+// mapping Mouser part number to release date (YYYY-MM-DD) to quantity. Parts
+// are ordered by part number and releases by date so the body is
+// deterministic. It returns an error wrapping ErrInvalidRequest if a part
+// number is empty, a part has no releases, a date does not parse, or a
+// quantity is not positive.
+func BuildSchedule(cartKey string, plans map[string]map[string]int) (ScheduleCartItemsRequestBody, error) {
+	body := ScheduleCartItemsRequestBody{CartKey: cartKey}
+
+	partNumbers := make([]string, 0, len(plans))
+	for pn := range plans {
+		partNumbers = append(partNumbers, pn)
+	}
+	sort.Strings(partNumbers)
+
+	for _, pn := range partNumbers {
+		if strings.TrimSpace(pn) == "" {
+			return ScheduleCartItemsRequestBody{}, fmt.Errorf("%w: schedule has an empty part number", ErrInvalidRequest)
+		}
+		releases := plans[pn]
+		if len(releases) == 0 {
+			return ScheduleCartItemsRequestBody{}, fmt.Errorf("%w: %s has no scheduled releases", ErrInvalidRequest, pn)
+		}
+
+		items := make([]ScheduleRelease, 0, len(releases))
+		for date, qty := range releases {
+			d, err := time.Parse(ScheduleDateFormat, date)
+			if err != nil {
+				return ScheduleCartItemsRequestBody{}, fmt.Errorf("%w: %s has invalid release date %q", ErrInvalidRequest, pn, date)
+			}
+			if qty <= 0 {
+				return ScheduleCartItemsRequestBody{}, fmt.Errorf("%w: %s has non-positive quantity %d on %s", ErrInvalidRequest, pn, qty, date)
+			}
+			items = append(items, ScheduleRelease{Key: d.Format(ScheduleDateFormat), Value: qty})
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+
+		body.ScheduleCartItems = append(body.ScheduleCartItems, ScheduleReleaseRequest{
+			MouserPartNumber:  pn,
+			ScheduledReleases: items,
+		})
+	}
+
+	return body, nil
+}
+
+// ReadyToOrder checks that the cart can be turned into an order. It returns
+// an error wrapping ErrCartNotReady if the cart is empty, if any line carries
+// API errors, or if any line has nothing available to ship (MouserATS of 0).
+// Lines with some stock but less than the requested quantity are considered
+// acceptable, since the remainder ships as a backorder.
+func (r *CartResponse) ReadyToOrder() error {
+	if len(r.CartItems) == 0 {
+		return fmt.Errorf("%w: cart is empty", ErrCartNotReady)
+	}
+
+	var problems []string
+	for _, line := range r.CartItems {
+		if len(line.Errors) > 0 {
+			problems = append(problems, fmt.Sprintf("%s: %s", line.MouserPartNumber, APIErrors(line.Errors).Error()))
+			continue
+		}
+		if ats, ok := parseQuantity(line.MouserATS); ok && ats == 0 {
+			problems = append(problems, fmt.Sprintf("%s: out of stock", line.MouserPartNumber))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrCartNotReady, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// LineErrors returns the API errors reported on individual cart lines,
+// keyed by MouserPartNumber, or nil if no line has errors. A cart request
+// can succeed overall while some lines fail, for example for an invalid
+// part number.
+func (r *CartResponse) LineErrors() map[string][]APIError {
+	var errs map[string][]APIError
+	for _, line := range r.CartItems {
+		if len(line.Errors) == 0 {
+			continue
+		}
+		if errs == nil {
+			errs = make(map[string][]APIError)
+		}
+		errs[line.MouserPartNumber] = append(errs[line.MouserPartNumber], line.Errors...)
+	}
+	return errs
+}
+
+// HasLineErrors reports whether any cart line carries API errors.
+func (r *CartResponse) HasLineErrors() bool {
+	for _, line := range r.CartItems {
+		if len(line.Errors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// merchandiseTotalEpsilon is the largest difference between the computed and
+// reported merchandise totals that TotalMismatch treats as rounding.
+const merchandiseTotalEpsilon = 0.005
+
+// ComputedMerchandiseTotal returns the sum of ExtendedPrice across the cart's
+// lines. It is 0 for an empty cart.
+func (r *CartResponse) ComputedMerchandiseTotal() float64 {
+	var total float64
+	for _, line := range r.CartItems {
+		total += line.ExtendedPrice
+	}
+	return total
+}
+
+// TotalMismatch compares the line-item total from ComputedMerchandiseTotal
+// (expected) with the MerchandiseTotal reported by the API (actual). ok is
+// true if they agree to within half a cent. An empty cart agrees only if its
+// reported total is also zero.
+func (r *CartResponse) TotalMismatch() (expected, actual float64, ok bool) {
+	expected = r.ComputedMerchandiseTotal()
+	actual = r.MerchandiseTotal
+	return expected, actual, math.Abs(expected-actual) <= merchandiseTotalEpsilon
+}
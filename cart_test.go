@@ -3,9 +3,15 @@ package mouser
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func cartSuccessResponse() string {
@@ -153,6 +159,112 @@ func TestInsertCartItemsMock(t *testing.T) {
 	}
 }
 
+// TestInsertCartItemsChunkedMock tests that large inserts are split into
+// sequential requests against the same cart.
+func TestInsertCartItemsChunkedMock(t *testing.T) {
+	var chunkSizes []int
+	var cartKeys []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req CartItemRequestBody
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to parse request: %v", err)
+		}
+		chunkSizes = append(chunkSizes, len(req.CartItems))
+		cartKeys = append(cartKeys, req.CartKey)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"Errors":[],"CartKey":"abc-123","TotalItemCount":%d}`, len(chunkSizes)*2)))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithRateLimiter(NewRateLimiter(10000, 100000)),
+		WithCartInsertChunkSize(2),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	items := make([]CartItemRequest, 5)
+	for i := range items {
+		items[i] = CartItemRequest{MouserPartNumber: fmt.Sprintf("TEST-%03d", i), Quantity: 1}
+	}
+
+	resp, err := client.Cart.InsertItems(context.Background(), CartItemRequestBody{CartItems: items}, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(chunkSizes) != 3 || chunkSizes[0] != 2 || chunkSizes[1] != 2 || chunkSizes[2] != 1 {
+		t.Errorf("expected chunks of [2 2 1], got %v", chunkSizes)
+	}
+	if cartKeys[0] != "" || cartKeys[1] != "abc-123" || cartKeys[2] != "abc-123" {
+		t.Errorf("expected later chunks to reuse the first cart key, got %v", cartKeys)
+	}
+	if resp.CartKey != "abc-123" || resp.TotalItemCount != 6 {
+		t.Errorf("expected final cart response, got %+v", resp)
+	}
+}
+
+// TestInsertCartItemsChunkFailsMock tests that a failed later chunk returns
+// the partially filled cart with a *CartInsertChunkError.
+func TestInsertCartItemsChunkFailsMock(t *testing.T) {
+	requests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if requests == 2 {
+			_, _ = w.Write([]byte(`{"Errors":[{"Code":"InvalidQuantity","Message":"Quantity is invalid"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"Errors":[],"CartKey":"abc-123","TotalItemCount":2}`))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithRateLimiter(NewRateLimiter(10000, 100000)),
+		WithCartInsertChunkSize(2),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	items := make([]CartItemRequest, 5)
+	for i := range items {
+		items[i] = CartItemRequest{MouserPartNumber: fmt.Sprintf("TEST-%03d", i), Quantity: 1}
+	}
+
+	resp, err := client.Cart.InsertItems(context.Background(), CartItemRequestBody{CartItems: items}, "", "")
+	var chunkErr *CartInsertChunkError
+	if !errors.As(err, &chunkErr) {
+		t.Fatalf("expected *CartInsertChunkError, got %v", err)
+	}
+	if chunkErr.CartKey != "abc-123" || chunkErr.Chunk != 2 || chunkErr.Chunks != 3 {
+		t.Errorf("unexpected chunk error %+v", chunkErr)
+	}
+	var apiErrs APIErrors
+	if !errors.As(err, &apiErrs) {
+		t.Errorf("expected the API errors to be wrapped, got %v", err)
+	}
+	if resp == nil || resp.CartKey != "abc-123" || resp.TotalItemCount != 2 {
+		t.Errorf("expected the cart after the first chunk, got %+v", resp)
+	}
+	if requests != 2 {
+		t.Errorf("expected no requests after the failed chunk, got %d", requests)
+	}
+}
+
 // TestUpdateCartItemsMock tests UpdateCartItems with a mock server.
 func TestUpdateCartItemsMock(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -212,6 +324,11 @@ func TestRemoveCartItemMock(t *testing.T) {
 	}
 }
 
+// futureScheduleDate returns the schedule date days from today.
+func futureScheduleDate(days int) string {
+	return time.Now().UTC().AddDate(0, 0, days).Format(ScheduleDateFormat)
+}
+
 // TestInsertCartScheduleMock tests InsertCartSchedule with a mock server.
 func TestInsertCartScheduleMock(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -242,7 +359,7 @@ func TestInsertCartScheduleMock(t *testing.T) {
 			{
 				MouserPartNumber: "TEST-001",
 				ScheduledReleases: []ScheduleRelease{
-					{Key: "2025-06-01", Value: 5},
+					{Key: futureScheduleDate(30), Value: 5},
 				},
 			},
 		},
@@ -276,7 +393,7 @@ func TestUpdateCartScheduleMock(t *testing.T) {
 			{
 				MouserPartNumber: "TEST-001",
 				ScheduledReleases: []ScheduleRelease{
-					{Key: "2025-06-01", Value: 10},
+					{Key: futureScheduleDate(30), Value: 10},
 				},
 			},
 		},
@@ -420,6 +537,507 @@ func TestScheduleCartModelRoundtrip(t *testing.T) {
 	}
 }
 
+// TestCartReadyToOrderEmpty tests that an empty cart is not ready.
+func TestCartReadyToOrderEmpty(t *testing.T) {
+	cart := CartResponse{CartKey: "abc-123"}
+
+	err := cart.ReadyToOrder()
+	if !errors.Is(err, ErrCartNotReady) {
+		t.Fatalf("expected ErrCartNotReady, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "empty") {
+		t.Errorf("expected message to mention empty cart, got %q", err.Error())
+	}
+}
+
+// TestCartReadyToOrderLineErrors tests that line-level errors and zero stock block ordering.
+func TestCartReadyToOrderLineErrors(t *testing.T) {
+	cart := CartResponse{
+		CartKey: "abc-123",
+		CartItems: []CartOrderLine{
+			{MouserPartNumber: "GOOD-001", Quantity: 5, MouserATS: "100"},
+			{MouserPartNumber: "BAD-001", Quantity: 5, MouserATS: "100", Errors: []APIError{{Code: "InvalidPartNumber", Message: "Part not found"}}},
+			{MouserPartNumber: "NOSTOCK-001", Quantity: 5, MouserATS: "0"},
+		},
+	}
+
+	err := cart.ReadyToOrder()
+	if !errors.Is(err, ErrCartNotReady) {
+		t.Fatalf("expected ErrCartNotReady, got %v", err)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "BAD-001") || !strings.Contains(msg, "Part not found") {
+		t.Errorf("expected line error in message, got %q", msg)
+	}
+	if !strings.Contains(msg, "NOSTOCK-001: out of stock") {
+		t.Errorf("expected out-of-stock line in message, got %q", msg)
+	}
+	if strings.Contains(msg, "GOOD-001") {
+		t.Errorf("did not expect ready line in message, got %q", msg)
+	}
+}
+
+// TestCartReadyToOrderReady tests a cart with in-stock and partially backordered lines.
+func TestCartReadyToOrderReady(t *testing.T) {
+	var cart CartResponse
+	if err := json.Unmarshal([]byte(cartSuccessResponse()), &cart); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	cart.CartItems = append(cart.CartItems, CartOrderLine{
+		MouserPartNumber: "BACKORDER-001",
+		Quantity:         5000,
+		MouserATS:        "1,200",
+	})
+
+	if err := cart.ReadyToOrder(); err != nil {
+		t.Errorf("expected cart to be ready, got %v", err)
+	}
+}
+
+// TestBuildSchedule tests building a multi-part, multi-release schedule.
+func TestBuildSchedule(t *testing.T) {
+	body, err := BuildSchedule("abc-123", map[string]map[string]int{
+		"TEST-002": {"2025-07-01": 200, "2025-06-01": 100},
+		"TEST-001": {"2025-06-15": 50},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if body.CartKey != "abc-123" {
+		t.Errorf("expected CartKey=abc-123, got %s", body.CartKey)
+	}
+	if len(body.ScheduleCartItems) != 2 {
+		t.Fatalf("expected 2 schedule items, got %d", len(body.ScheduleCartItems))
+	}
+	if body.ScheduleCartItems[0].MouserPartNumber != "TEST-001" || body.ScheduleCartItems[1].MouserPartNumber != "TEST-002" {
+		t.Errorf("expected parts sorted by part number, got %+v", body.ScheduleCartItems)
+	}
+
+	releases := body.ScheduleCartItems[1].ScheduledReleases
+	if len(releases) != 2 {
+		t.Fatalf("expected 2 releases, got %d", len(releases))
+	}
+	if releases[0] != (ScheduleRelease{Key: "2025-06-01", Value: 100}) || releases[1] != (ScheduleRelease{Key: "2025-07-01", Value: 200}) {
+		t.Errorf("expected releases sorted by date, got %+v", releases)
+	}
+}
+
+// TestBuildScheduleValidation tests that invalid plans are rejected.
+func TestBuildScheduleValidation(t *testing.T) {
+	testCases := []struct {
+		name  string
+		plans map[string]map[string]int
+	}{
+		{"empty part number", map[string]map[string]int{"": {"2025-06-01": 1}}},
+		{"no releases", map[string]map[string]int{"TEST-001": {}}},
+		{"bad date", map[string]map[string]int{"TEST-001": {"06/01/2025": 1}}},
+		{"zero quantity", map[string]map[string]int{"TEST-001": {"2025-06-01": 0}}},
+		{"negative quantity", map[string]map[string]int{"TEST-001": {"2025-06-01": -5}}},
+	}
+
+	for _, tc := range testCases {
+		if _, err := BuildSchedule("abc-123", tc.plans); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: expected ErrInvalidRequest, got %v", tc.name, err)
+		}
+	}
+}
+
+// TestCartTotalMismatch tests reconciling line totals against the merchandise total.
+func TestCartTotalMismatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cart     CartResponse
+		expected float64
+		ok       bool
+	}{
+		{
+			name: "empty",
+			cart: CartResponse{},
+			ok:   true,
+		},
+		{
+			name: "empty with reported total",
+			cart: CartResponse{MerchandiseTotal: 4.5},
+			ok:   false,
+		},
+		{
+			name: "matching with rounding",
+			cart: CartResponse{
+				CartItems:        []CartOrderLine{{ExtendedPrice: 0.1}, {ExtendedPrice: 0.2}, {ExtendedPrice: 12.34}},
+				MerchandiseTotal: 12.64,
+			},
+			expected: 12.64,
+			ok:       true,
+		},
+		{
+			name: "mismatched",
+			cart: CartResponse{
+				CartItems:        []CartOrderLine{{ExtendedPrice: 15}, {ExtendedPrice: 4.5}},
+				MerchandiseTotal: 19.52,
+			},
+			expected: 19.5,
+			ok:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expected, actual, ok := tc.cart.TotalMismatch()
+			if ok != tc.ok {
+				t.Errorf("expected ok=%v, got %v (expected %v, actual %v)", tc.ok, ok, expected, actual)
+			}
+			if math.Abs(expected-tc.expected) > 1e-9 {
+				t.Errorf("expected computed total %v, got %v", tc.expected, expected)
+			}
+			if actual != tc.cart.MerchandiseTotal {
+				t.Errorf("expected actual %v, got %v", tc.cart.MerchandiseTotal, actual)
+			}
+		})
+	}
+}
+
+// TestCartEnrichMock tests resolving the full Part for each cart line.
+func TestCartEnrichMock(t *testing.T) {
+	catalog := []Part{
+		{MouserPartNumber: "595-NE555P", ManufacturerPartNumber: "NE555P", DataSheetUrl: "https://example.com/ne555.pdf"},
+		{MouserPartNumber: "621-1N4148W-F", ManufacturerPartNumber: "1N4148W-F", ROHSStatus: "RoHS Compliant"},
+	}
+	client := newTestClient(t, partCatalogHandler(t, catalog))
+
+	cart := &CartResponse{CartItems: []CartOrderLine{
+		{MouserPartNumber: "595-NE555P", Quantity: 10},
+		{MouserPartNumber: "621-1N4148W-F", Quantity: 100},
+	}}
+	parts, err := client.Cart.Enrich(context.Background(), cart)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(parts))
+	}
+	if p := parts["595-NE555P"]; p == nil || p.DataSheetUrl != "https://example.com/ne555.pdf" {
+		t.Errorf("unexpected part for 595-NE555P: %+v", p)
+	}
+	if p := parts["621-1N4148W-F"]; p == nil || p.ROHSStatus != "RoHS Compliant" {
+		t.Errorf("unexpected part for 621-1N4148W-F: %+v", p)
+	}
+
+	cart.CartItems = append(cart.CartItems, CartOrderLine{MouserPartNumber: "NOPE-1"})
+	parts, err = client.Cart.Enrich(context.Background(), cart)
+	var unresolved *UnresolvedPartsError
+	if !errors.As(err, &unresolved) || strings.Join(unresolved.PartNumbers, ",") != "NOPE-1" {
+		t.Fatalf("expected NOPE-1 unresolved, got %v", err)
+	}
+	if len(parts) != 2 {
+		t.Errorf("expected partial result with 2 parts, got %d", len(parts))
+	}
+}
+
+// TestRemoveItemsMock tests bulk removal through a zero-quantity update and
+// the per-item fallback.
+func TestRemoveItemsMock(t *testing.T) {
+	tests := []struct {
+		name        string
+		updateReply func(w http.ResponseWriter)
+		wantRemoved string
+	}{
+		{"update clears lines", func(w http.ResponseWriter) {
+			_, _ = w.Write([]byte(`{"Errors":[],"CartKey":"abc-123","CartItems":[{"MouserPartNumber":"KEEP-1","Quantity":1}]}`))
+		}, ""},
+		{"update leaves a line", func(w http.ResponseWriter) {
+			_, _ = w.Write([]byte(`{"Errors":[],"CartKey":"abc-123","CartItems":[{"MouserPartNumber":"KEEP-1","Quantity":1},{"MouserPartNumber":"B-2","Quantity":5}]}`))
+		}, "B-2"},
+		{"update rejected", func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusBadRequest)
+		}, "A-1,B-2"},
+		{"update domain error", func(w http.ResponseWriter) {
+			_, _ = w.Write([]byte(`{"Errors":[{"Code":"InvalidQuantity","Message":"Quantity must be positive"}],"CartItems":[]}`))
+		}, "A-1,B-2"},
+		{"update conflict", func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusConflict)
+		}, "A-1,B-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var removed []string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/cart/items/update":
+					var body CartItemRequestBody
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					if len(body.CartItems) != 2 || body.CartItems[0].Quantity != 0 {
+						t.Errorf("expected 2 zero-quantity items, got %+v", body.CartItems)
+					}
+					tt.updateReply(w)
+				case "/cart/item/remove":
+					removed = append(removed, r.URL.Query().Get("mouserPartNumber"))
+					_, _ = w.Write([]byte(`{"Errors":[],"CartKey":"abc-123","CartItems":[{"MouserPartNumber":"KEEP-1","Quantity":1}]}`))
+				default:
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+			})
+
+			client := newTestClient(t, handler)
+			cart, err := client.Cart.RemoveItems(context.Background(), "abc-123", []string{"A-1", "B-2", "A-1"}, "US", "USD")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := strings.Join(removed, ","); got != tt.wantRemoved {
+				t.Errorf("expected RemoveItem calls for %q, got %q", tt.wantRemoved, got)
+			}
+			if len(cart.CartItems) != 1 || cart.CartItems[0].MouserPartNumber != "KEEP-1" {
+				t.Errorf("unexpected final cart: %+v", cart.CartItems)
+			}
+		})
+	}
+}
+
+// TestInsertItemsRoundedMock tests that quantities are rounded to legal
+// order quantities before insertion and that adjustments are reported.
+func TestInsertItemsRoundedMock(t *testing.T) {
+	catalog := partCatalogHandler(t, []Part{
+		{MouserPartNumber: "595-NE555P", Min: "1", Mult: "1"},
+		{MouserPartNumber: "81-GRM188R71H104KA3D", Min: "10", Mult: "10"},
+	})
+
+	var inserted []CartItemRequest
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cart/items/insert" {
+			catalog.ServeHTTP(w, r)
+			return
+		}
+		var body CartItemRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to parse insert: %v", err)
+		}
+		inserted = body.CartItems
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(cartSuccessResponse()))
+	})
+
+	client := newTestClient(t, handler)
+	_, adjustments, err := client.Cart.InsertItemsRounded(context.Background(), CartItemRequestBody{CartItems: []CartItemRequest{
+		{MouserPartNumber: "595-NE555P", Quantity: 3},
+		{MouserPartNumber: "81-GRM188R71H104KA3D", Quantity: 25},
+		{MouserPartNumber: "UNKNOWN-1", Quantity: 2},
+	}}, "US", "USD")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []int{3, 30, 2}
+	for i, item := range inserted {
+		if item.Quantity != want[i] {
+			t.Errorf("item %s: expected quantity %d, got %d", item.MouserPartNumber, want[i], item.Quantity)
+		}
+	}
+	if len(adjustments) != 1 || adjustments[0].MouserPartNumber != "81-GRM188R71H104KA3D" ||
+		adjustments[0].Requested != 25 || adjustments[0].Adjusted != 30 {
+		t.Errorf("unexpected adjustments: %+v", adjustments)
+	}
+}
+
+// TestCartLineErrors tests grouping line-level errors by part number.
+func TestCartLineErrors(t *testing.T) {
+	cart := &CartResponse{CartItems: []CartOrderLine{
+		{MouserPartNumber: "GOOD-1"},
+		{MouserPartNumber: "BAD-1", Errors: []APIError{{Code: "InvalidPartNumber", Message: "Part not found"}}},
+		{MouserPartNumber: "BAD-2", Errors: []APIError{{Code: "Quantity", Message: "Below minimum"}}},
+	}}
+
+	if !cart.HasLineErrors() {
+		t.Error("expected HasLineErrors to be true")
+	}
+	errs := cart.LineErrors()
+	if len(errs) != 2 || errs["BAD-1"][0].Code != "InvalidPartNumber" || errs["BAD-2"][0].Message != "Below minimum" {
+		t.Errorf("unexpected line errors %+v", errs)
+	}
+
+	clean := &CartResponse{CartItems: []CartOrderLine{{MouserPartNumber: "GOOD-1"}}}
+	if clean.HasLineErrors() || clean.LineErrors() != nil {
+		t.Error("expected no line errors for a clean cart")
+	}
+}
+
+// TestStrictCartLinesMock tests that WithStrictCartLines surfaces line
+// errors as an error while still returning the cart.
+func TestStrictCartLinesMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"Errors": [],
+			"CartKey": "abc-123",
+			"CartItems": [
+				{"MouserPartNumber": "TEST-001", "Quantity": 5, "Errors": []},
+				{"MouserPartNumber": "NOPE-1", "Quantity": 1, "Errors": [{"Code": "InvalidPartNumber", "Message": "Invalid part number"}]}
+			]
+		}`))
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	body := CartItemRequestBody{CartItems: []CartItemRequest{{MouserPartNumber: "TEST-001", Quantity: 5}, {MouserPartNumber: "NOPE-1", Quantity: 1}}}
+
+	lenient := newTestClient(t, handler)
+	resp, err := lenient.Cart.InsertItems(context.Background(), body, "", "")
+	if err != nil || !resp.HasLineErrors() {
+		t.Fatalf("expected success with line errors by default, got %v", err)
+	}
+
+	strict, err := NewClient("test-key", WithBaseURL(server.URL), WithoutRetry(), WithoutCache(), WithStrictCartLines())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer strict.Close()
+
+	calls := map[string]func() (*CartResponse, error){
+		"InsertItems": func() (*CartResponse, error) { return strict.Cart.InsertItems(context.Background(), body, "", "") },
+		"UpdateItems": func() (*CartResponse, error) { return strict.Cart.UpdateItems(context.Background(), body, "", "") },
+		"Update":      func() (*CartResponse, error) { return strict.Cart.Update(context.Background(), body, "", "") },
+		"UpdateItemsWithRetry": func() (*CartResponse, error) {
+			return strict.Cart.UpdateItemsWithRetry(context.Background(), "abc-123", "", "", func(*CartResponse) CartItemRequestBody { return body })
+		},
+	}
+	for name, call := range calls {
+		resp, err := call()
+		var lineErr *CartLineErrorsError
+		if !errors.As(err, &lineErr) || !errors.Is(err, ErrCartLineErrors) {
+			t.Errorf("%s: expected *CartLineErrorsError, got %v", name, err)
+			continue
+		}
+		if resp == nil || resp.CartKey != "abc-123" {
+			t.Errorf("%s: expected the cart alongside the error, got %+v", name, resp)
+		}
+		if lineErr.CartKey != "abc-123" || len(lineErr.LineErrors) != 1 || lineErr.LineErrors["NOPE-1"] == nil {
+			t.Errorf("%s: unexpected error contents %+v", name, lineErr)
+		}
+		if !strings.Contains(err.Error(), "NOPE-1") {
+			t.Errorf("%s: expected the failed part in the message, got %q", name, err)
+		}
+	}
+}
+
+// TestCartItemValidate tests NewCartItem and CartItemRequest.Validate.
+func TestCartItemValidate(t *testing.T) {
+	item := NewCartItem("595-NE555DR", 10, CartItemPackaging(PackagingChoiceCutTape), CartItemCustomerPartNumber("U1"))
+	if item.MouserPartNumber != "595-NE555DR" || item.Quantity != 10 || item.PackagingChoice != PackagingChoiceCutTape || item.CustomerPartNumber != "U1" {
+		t.Errorf("unexpected item %+v", item)
+	}
+	if err := item.Validate(); err != nil {
+		t.Errorf("expected valid item, got %v", err)
+	}
+
+	invalid := []CartItemRequest{
+		NewCartItem("", 1),
+		NewCartItem("595-NE555DR", 0),
+		NewCartItem("595-NE555DR", -1),
+		NewCartItem("595-NE555DR", 1, CartItemPackaging("Tray")),
+	}
+	for _, item := range invalid {
+		if err := item.Validate(); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("Validate(%+v) = %v, want ErrInvalidRequest", item, err)
+		}
+	}
+}
+
+// TestCartItemValidationMock tests that invalid items are rejected before a
+// request is sent, and that UpdateItems accepts a zero quantity.
+func TestCartItemValidationMock(t *testing.T) {
+	requests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(cartSuccessResponse()))
+	})
+	client := newTestClient(t, handler)
+	ctx := context.Background()
+
+	body := CartItemRequestBody{CartItems: []CartItemRequest{NewCartItem("TEST-001", 0)}}
+	if _, err := client.Cart.InsertItems(ctx, body, "", ""); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("InsertItems: expected ErrInvalidRequest, got %v", err)
+	}
+	if _, _, err := client.Cart.InsertItemsRounded(ctx, body, "", ""); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("InsertItemsRounded: expected ErrInvalidRequest, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests for invalid items, got %d", requests)
+	}
+
+	if _, err := client.Cart.UpdateItems(ctx, body, "", ""); err != nil {
+		t.Errorf("UpdateItems with zero quantity: %v", err)
+	}
+	body.CartItems[0].PackagingChoice = "Tray"
+	if _, err := client.Cart.UpdateItems(ctx, body, "", ""); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("UpdateItems: expected ErrInvalidRequest, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+// TestScheduleReleaseValidate tests schedule validation, totals, and
+// sorting.
+func TestScheduleReleaseValidate(t *testing.T) {
+	schedule := ScheduleReleaseRequest{
+		MouserPartNumber: "TEST-001",
+		ScheduledReleases: []ScheduleRelease{
+			{Key: futureScheduleDate(60), Value: 200},
+			{Key: futureScheduleDate(30), Value: 100},
+		},
+	}
+	if err := schedule.Validate(); err != nil {
+		t.Errorf("expected valid schedule, got %v", err)
+	}
+	if got := schedule.TotalQuantity(); got != 300 {
+		t.Errorf("TotalQuantity() = %d, want 300", got)
+	}
+	schedule.SortReleases()
+	if schedule.ScheduledReleases[0].Key != futureScheduleDate(30) {
+		t.Errorf("expected releases sorted by date, got %+v", schedule.ScheduledReleases)
+	}
+
+	invalid := map[string][]ScheduleRelease{
+		"empty":     nil,
+		"malformed": {{Key: "06/01/2030", Value: 1}},
+		"today":     {{Key: futureScheduleDate(0), Value: 1}},
+		"past":      {{Key: "2020-01-01", Value: 1}},
+		"duplicate": {{Key: futureScheduleDate(30), Value: 1}, {Key: futureScheduleDate(30), Value: 2}},
+		"quantity":  {{Key: futureScheduleDate(30), Value: 0}},
+	}
+	for name, releases := range invalid {
+		r := ScheduleReleaseRequest{MouserPartNumber: "TEST-001", ScheduledReleases: releases}
+		if err := r.Validate(); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: expected ErrInvalidRequest, got %v", name, err)
+		}
+	}
+}
+
+// TestInsertScheduleValidationMock tests that invalid schedules are
+// rejected before a request is sent.
+func TestInsertScheduleValidationMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected for an invalid schedule")
+	})
+	client := newTestClient(t, handler)
+
+	body := ScheduleCartItemsRequestBody{
+		CartKey: "abc-123",
+		ScheduleCartItems: []ScheduleReleaseRequest{{
+			MouserPartNumber:  "TEST-001",
+			ScheduledReleases: []ScheduleRelease{{Key: "2025-13-01", Value: 5}},
+		}},
+	}
+	if _, err := client.Cart.InsertSchedule(context.Background(), body); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("InsertSchedule: expected ErrInvalidRequest, got %v", err)
+	}
+	if _, err := client.Cart.UpdateSchedule(context.Background(), body); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("UpdateSchedule: expected ErrInvalidRequest, got %v", err)
+	}
+}
+
 // Integration tests - gated by MOUSER_API_KEY
 
 // TestIntegrationCartInsertAndGet tests inserting items into a cart and retrieving the cart.
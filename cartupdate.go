@@ -0,0 +1,138 @@
+package mouser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// cartUpdateMaxAttempts bounds the read-modify-write cycles of
+// Cart.UpdateItemsWithRetry.
+const cartUpdateMaxAttempts = 3
+
+// UpdateItemsWithRetry performs a read-modify-write update of a cart: it
+// gets the cart, passes it to mutate, and sends the returned items with
+// UpdateItems. If mutate returns no items, nothing is sent and the cart as
+// read is returned.
+//
+// Mouser carts carry no version, so the API cannot reject an update based
+// on a stale read. Instead, cycles on the same cart key are serialized
+// within this Client, so that concurrent goroutines never interleave their
+// reads and writes. A cycle is repeated, up to three times in all and with
+// the client's retry backoff, when Mouser answers with HTTP 409 Conflict or
+// 412 Precondition Failed, or with an API error whose code contains
+// "Conflict"; mutate is then called again with the fresh cart.
+// A *CartLineErrorsError from WithStrictCartLines is returned together with
+// the updated cart, without a retry.
+func (s *CartService) UpdateItemsWithRetry(ctx context.Context, cartKey, countryCode, currencyCode string, mutate func(*CartResponse) CartItemRequestBody) (*CartResponse, error) {
+	c := s.client
+
+	if strings.TrimSpace(cartKey) == "" {
+		return nil, fmt.Errorf("%w: cart key is required", ErrInvalidRequest)
+	}
+
+	unlock, err := c.lockCart(ctx, cartKey)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var lastErr error
+	var backoff time.Duration
+	for attempt := 0; attempt < cartUpdateMaxAttempts; attempt++ {
+		if attempt > 0 {
+			backoff = c.retryConfig.calculateBackoff(attempt-1, backoff)
+			if err := sleep(ctx, backoff); err != nil {
+				return nil, err
+			}
+		}
+
+		cart, err := s.Get(ctx, cartKey, countryCode, currencyCode)
+		if err != nil {
+			return nil, err
+		}
+
+		body := mutate(cart)
+		if len(body.CartItems) == 0 {
+			return cart, nil
+		}
+		body.CartKey = cartKey
+
+		updated, err := s.UpdateItems(ctx, body, countryCode, currencyCode)
+		if err == nil {
+			return updated, nil
+		}
+		if isCartLineErrors(err) {
+			return updated, err
+		}
+		if !isCartConflict(err) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// cartLock serializes UpdateItemsWithRetry cycles on one cart. refs counts
+// the holder and waiters so the entry can be dropped once nobody needs it.
+type cartLock struct {
+	ch   chan struct{}
+	refs int
+}
+
+// lockCart waits until no other UpdateItemsWithRetry cycle holds cartKey on
+// this client, or ctx is done, and returns the function that releases it.
+func (c *Client) lockCart(ctx context.Context, cartKey string) (func(), error) {
+	c.cartLocksMu.Lock()
+	if c.cartLocks == nil {
+		c.cartLocks = make(map[string]*cartLock)
+	}
+	lock := c.cartLocks[cartKey]
+	if lock == nil {
+		lock = &cartLock{ch: make(chan struct{}, 1)}
+		c.cartLocks[cartKey] = lock
+	}
+	lock.refs++
+	c.cartLocksMu.Unlock()
+
+	release := func() {
+		c.cartLocksMu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(c.cartLocks, cartKey)
+		}
+		c.cartLocksMu.Unlock()
+	}
+
+	select {
+	case lock.ch <- struct{}{}:
+		return func() {
+			<-lock.ch
+			release()
+		}, nil
+	case <-ctx.Done():
+		release()
+		return nil, ctx.Err()
+	}
+}
+
+// isCartConflict reports whether err says that a cart changed underneath an
+// update.
+func isCartConflict(err error) bool {
+	var mErr *MouserError
+	if errors.As(err, &mErr) && (mErr.StatusCode == http.StatusConflict || mErr.StatusCode == http.StatusPreconditionFailed) {
+		return true
+	}
+	var apiErrs APIErrors
+	if errors.As(err, &apiErrs) {
+		for _, apiErr := range apiErrs {
+			if strings.Contains(strings.ToLower(apiErr.Code), "conflict") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package mouser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// cartStateHandler serves a single cart whose line quantities are updated
+// in place. The first failUpdates updates are rejected with 409 Conflict.
+func cartStateHandler(t *testing.T, failUpdates int) (http.Handler, func() int) {
+	var mu sync.Mutex
+	quantity := 0
+	updates := 0
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/cart":
+		case "/cart/items/update":
+			updates++
+			if updates <= failUpdates {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			var body CartItemRequestBody
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("failed to parse update: %v", err)
+			}
+			// Widen the window in which unserialized updates would race.
+			time.Sleep(time.Millisecond)
+			quantity = body.CartItems[0].Quantity
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"Errors":[],"CartKey":"abc-123","CartItems":[{"MouserPartNumber":"595-NE555P","Quantity":%d}]}`, quantity)
+	})
+
+	return handler, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return quantity
+	}
+}
+
+// incrementLine returns a mutation that adds one to the first cart line.
+func incrementLine(cart *CartResponse) CartItemRequestBody {
+	line := cart.CartItems[0]
+	return CartItemRequestBody{CartItems: []CartItemRequest{{MouserPartNumber: line.MouserPartNumber, Quantity: line.Quantity + 1}}}
+}
+
+// TestUpdateItemsWithRetrySerializedMock tests that concurrent
+// read-modify-write cycles on one cart do not lose updates.
+func TestUpdateItemsWithRetrySerializedMock(t *testing.T) {
+	handler, quantity := cartStateHandler(t, 0)
+	client := newTestClient(t, handler)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Cart.UpdateItemsWithRetry(context.Background(), "abc-123", "US", "USD", incrementLine); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := quantity(); got != 5 {
+		t.Errorf("expected quantity 5 after 5 increments, got %d", got)
+	}
+}
+
+// TestUpdateItemsWithRetryConflictMock tests that a conflict re-reads the
+// cart and retries.
+func TestUpdateItemsWithRetryConflictMock(t *testing.T) {
+	handler, _ := cartStateHandler(t, 1)
+	client := newTestClient(t, handler)
+	client.retryConfig.InitialBackoff = time.Millisecond
+
+	calls := 0
+	cart, err := client.Cart.UpdateItemsWithRetry(context.Background(), "abc-123", "US", "USD", func(c *CartResponse) CartItemRequestBody {
+		calls++
+		return incrementLine(c)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected mutate to run twice, got %d", calls)
+	}
+	if cart.CartItems[0].Quantity != 1 {
+		t.Errorf("expected quantity 1, got %d", cart.CartItems[0].Quantity)
+	}
+}
+
+// TestUpdateItemsWithRetryGivesUpMock tests that persistent conflicts are
+// returned after the last attempt.
+func TestUpdateItemsWithRetryGivesUpMock(t *testing.T) {
+	handler, _ := cartStateHandler(t, cartUpdateMaxAttempts)
+	client := newTestClient(t, handler)
+	client.retryConfig.InitialBackoff = time.Millisecond
+
+	_, err := client.Cart.UpdateItemsWithRetry(context.Background(), "abc-123", "US", "USD", incrementLine)
+	var mErr *MouserError
+	if !errors.As(err, &mErr) || mErr.StatusCode != http.StatusConflict {
+		t.Errorf("expected a 409 MouserError, got %v", err)
+	}
+}
+
+// TestLockCartReleasesEntries tests that cart locks are dropped once no
+// holder or waiter needs them, including waiters that give up.
+func TestLockCartReleasesEntries(t *testing.T) {
+	client, err := NewClient("test-key")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	unlock, err := client.lockCart(context.Background(), "abc-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.lockCart(ctx, "abc-123"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the second lock to time out, got %v", err)
+	}
+
+	unlock()
+	for i := 0; i < 3; i++ {
+		unlock, err := client.lockCart(context.Background(), fmt.Sprintf("cart-%d", i))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		unlock()
+	}
+
+	client.cartLocksMu.Lock()
+	defer client.cartLocksMu.Unlock()
+	if len(client.cartLocks) != 0 {
+		t.Errorf("expected no cart locks left, got %d", len(client.cartLocks))
+	}
+}
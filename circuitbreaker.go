@@ -0,0 +1,158 @@
+package mouser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// circuitState is the state of a circuitBreaker.
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// String returns the state's name as reported by Client.Status.
+func (s circuitState) String() string {
+	switch s {
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// circuitBreaker stops requests after repeated transport failures.
+//
+// It opens after threshold consecutive failures and rejects requests until
+// cooldown has elapsed. It then lets a single trial request through: success
+// closes the circuit, failure reopens it for another cooldown.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+
+	state    circuitState
+	failures int
+	openedAt time.Time
+	trial    bool // a half-open trial request is in flight
+}
+
+// newCircuitBreaker creates a closed circuit breaker.
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow reports whether a request may proceed. It returns an error wrapping
+// ErrCircuitOpen if the circuit is open or a trial request is in flight.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		retryAt := b.openedAt.Add(b.cooldown)
+		if time.Now().Before(retryAt) {
+			return fmt.Errorf("%w until %s", ErrCircuitOpen, retryAt.Format(time.RFC3339))
+		}
+		b.state = circuitHalfOpen
+		b.trial = true
+		return nil
+	case circuitHalfOpen:
+		if b.trial {
+			return fmt.Errorf("%w: trial request in progress", ErrCircuitOpen)
+		}
+		b.trial = true
+		return nil
+	}
+	return nil
+}
+
+// recordSuccess closes the circuit and resets the failure count.
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = circuitClosed
+	b.failures = 0
+	b.trial = false
+}
+
+// recordFailure counts a transport failure, opening the circuit once the
+// threshold is reached or immediately if a trial request failed.
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+	}
+	b.trial = false
+}
+
+// recordNeutral releases a trial request whose outcome says nothing about
+// the API's health, such as one canceled by the caller.
+func (b *circuitBreaker) recordNeutral() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+// currentState returns the circuit's state. An open circuit whose cooldown
+// has elapsed is still reported as open until the next request tries it.
+func (b *circuitBreaker) currentState() circuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// record classifies the outcome of a request attempt made with ctx.
+func (b *circuitBreaker) record(ctx context.Context, err error, statusCode int) {
+	switch {
+	case err == nil:
+		b.recordSuccess()
+	case isTransportFailure(ctx, err, statusCode):
+		b.recordFailure()
+	case statusCode > 0:
+		// The API answered, so it is reachable.
+		b.recordSuccess()
+	default:
+		b.recordNeutral()
+	}
+}
+
+// isTransportFailure reports whether an attempt failed because the API was
+// unreachable or erroring: a 5xx response or a failed HTTP round trip,
+// including one cut off by the HTTP client's own timeout. Cancellations and
+// deadlines of the attempt's context and locally enforced rate limits do
+// not count.
+func isTransportFailure(ctx context.Context, err error, statusCode int) bool {
+	if statusCode >= 500 {
+		return true
+	}
+	if statusCode != 0 {
+		return false
+	}
+	if ctx.Err() != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
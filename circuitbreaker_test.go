@@ -0,0 +1,172 @@
+package mouser
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreakerTransitions tests the closed, open, and half-open states.
+func TestCircuitBreakerTransitions(t *testing.T) {
+	b := newCircuitBreaker(2, 50*time.Millisecond)
+
+	b.recordFailure()
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected closed circuit below threshold, got %v", err)
+	}
+
+	b.recordFailure()
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen at threshold, got %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected trial request after cooldown, got %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected concurrent request to be rejected during trial, got %v", err)
+	}
+
+	// A failed trial reopens the circuit immediately.
+	b.recordFailure()
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected reopened circuit after failed trial, got %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected trial request after cooldown, got %v", err)
+	}
+	b.recordSuccess()
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected closed circuit after successful trial, got %v", err)
+	}
+}
+
+// TestCircuitBreakerRecordClassification tests which outcomes count as failures.
+func TestCircuitBreakerRecordClassification(t *testing.T) {
+	b := newCircuitBreaker(1, time.Minute)
+	ctx := context.Background()
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	b.record(ctx, &MouserError{StatusCode: http.StatusNotFound}, http.StatusNotFound)
+	b.record(cancelled, &url.Error{Op: "Get", URL: "https://api.mouser.com", Err: context.Canceled}, 0)
+	b.record(ctx, &RateLimitError{Type: "minute"}, 0)
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected 4xx, cancellation, and local rate limits not to open the circuit, got %v", err)
+	}
+
+	b.record(ctx, &MouserError{StatusCode: http.StatusServiceUnavailable}, http.StatusServiceUnavailable)
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected 5xx to open the circuit, got %v", err)
+	}
+}
+
+// TestCircuitBreakerClientMock tests that an open circuit short-circuits calls.
+func TestCircuitBreakerClientMock(t *testing.T) {
+	var calls int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithCircuitBreaker(2, 50*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := client.doRequest(ctx, "GET", "/test", nil, nil); !errors.Is(err, ErrServerError) {
+			t.Fatalf("call %d: expected ErrServerError, got %v", i, err)
+		}
+	}
+
+	if err := client.doRequest(ctx, "GET", "/test", nil, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("expected open circuit to skip the server, got %d calls", n)
+	}
+
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+
+	var resp map[string]string
+	if err := client.doRequest(ctx, "GET", "/test", nil, &resp); err != nil {
+		t.Fatalf("expected trial request to succeed, got %v", err)
+	}
+	if err := client.doRequest(ctx, "GET", "/test", nil, &resp); err != nil {
+		t.Fatalf("expected closed circuit after recovery, got %v", err)
+	}
+}
+
+// TestCircuitBreakerHungServerMock tests that HTTP client timeouts against a
+// server that never responds open the circuit, while the caller's own
+// deadline does not.
+func TestCircuitBreakerHungServerMock(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithHTTPClient(&http.Client{Timeout: 20 * time.Millisecond}),
+		WithoutRetry(),
+		WithoutCache(),
+		WithCircuitBreaker(2, time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	var resp map[string]string
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		if err := client.doRequest(ctx, "GET", "/test", nil, &resp); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the caller's deadline, got %v", err)
+		}
+	}
+	if err := client.circuitBreaker.allow(); err != nil {
+		t.Fatalf("expected caller deadlines not to open the circuit, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := client.doRequest(context.Background(), "GET", "/test", nil, &resp); err == nil {
+			t.Fatal("expected a client timeout")
+		}
+	}
+	if err := client.doRequest(context.Background(), "GET", "/test", nil, &resp); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected client timeouts to open the circuit, got %v", err)
+	}
+}
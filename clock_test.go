@@ -0,0 +1,200 @@
+package mouser
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that only moves when advanced.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- f.now
+		return ch
+	}
+	f.waiters = append(f.waiters, fakeWaiter{at: f.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward and fires the timers that are due.
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = f.now.Add(d)
+	pending := f.waiters[:0]
+	for _, w := range f.waiters {
+		if w.at.After(f.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- f.now
+	}
+	f.waiters = pending
+}
+
+// waitForTimer blocks until something is waiting on the clock.
+func (f *fakeClock) waitForTimer(t *testing.T) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		f.mu.Lock()
+		n := len(f.waiters)
+		f.mu.Unlock()
+		if n > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("timed out waiting for a timer")
+}
+
+// TestWithClockRateLimiter tests that the rate limiter's windows and waits
+// follow the client's clock.
+func TestWithClockRateLimiter(t *testing.T) {
+	clock := newFakeClock()
+	client, err := NewClient("test-key", WithClock(clock), WithoutCache())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	limiter := client.rateLimiter
+	for i := 0; i < DefaultRequestsPerMinute; i++ {
+		if err := limiter.Allow(); err != nil {
+			t.Fatalf("Allow %d: %v", i+1, err)
+		}
+	}
+	if err := limiter.Allow(); err == nil {
+		t.Fatal("expected the minute limit to be exhausted")
+	}
+	if got := limiter.Stats().MinuteResetAt; !got.Equal(clock.Now().Add(time.Minute)) {
+		t.Errorf("MinuteResetAt = %v, want one fake minute from now", got)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- limiter.Wait(context.Background()) }()
+	clock.waitForTimer(t)
+	clock.Advance(time.Minute)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Wait: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return after the clock advanced")
+	}
+}
+
+// TestWithClockRetryAfterAndStatus tests that RetryAfter and Status judge
+// rate limiter times by the client's clock.
+func TestWithClockRetryAfterAndStatus(t *testing.T) {
+	clock := newFakeClock()
+	client, err := NewClient("test-key", WithClock(clock), WithoutCache())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	limiter := client.rateLimiter
+	for i := 0; i < DefaultRequestsPerMinute; i++ {
+		if err := limiter.Allow(); err != nil {
+			t.Fatalf("Allow %d: %v", i+1, err)
+		}
+	}
+	err = limiter.Allow()
+	clock.Advance(20 * time.Second)
+	if d, ok := RetryAfter(err); !ok || d != 40*time.Second {
+		t.Errorf("RetryAfter = %v, %v; want 40s on the fake clock", d, ok)
+	}
+
+	limiter.UpdateFromResponse(10)
+	if client.Status().RateLimit.BlockedUntil == nil {
+		t.Error("expected BlockedUntil while blocked on the fake clock")
+	}
+	clock.Advance(11 * time.Second)
+	if got := client.Status().RateLimit.BlockedUntil; got != nil {
+		t.Errorf("expected no BlockedUntil after the fake block expired, got %v", got)
+	}
+}
+
+// TestWithClockCustomLimiterAndCache tests that a rate limiter and cache
+// passed in keep their own clocks.
+func TestWithClockCustomLimiterAndCache(t *testing.T) {
+	limiter := NewRateLimiter(1, 100)
+	cache := NewMemoryCache(time.Minute)
+	client, err := NewClient("test-key", WithRateLimiter(limiter), WithCache(cache), WithClock(newFakeClock()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	if _, ok := limiter.clock.(realClock); !ok {
+		t.Errorf("expected the passed-in rate limiter to keep the real clock, got %T", limiter.clock)
+	}
+	if _, ok := cache.clock.(realClock); !ok {
+		t.Errorf("expected the passed-in cache to keep the real clock, got %T", cache.clock)
+	}
+}
+
+// TestWithClockCache tests that cached responses expire on the client's
+// clock.
+func TestWithClockCache(t *testing.T) {
+	clock := newFakeClock()
+	requests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Errors":[],"SearchResults":{"NumberOfResult":0,"Parts":[]}}`))
+	})
+	client := newTestClientCached(t, handler)
+	client.rateLimiter.setClock(clock)
+	client.cache.(*MemoryCache).setClock(clock)
+
+	ctx := context.Background()
+	opts := SearchOptions{Keyword: "NE555"}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Search.KeywordSearch(ctx, opts); err != nil {
+			t.Fatalf("KeywordSearch: %v", err)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 request before expiry, got %d", requests)
+	}
+
+	clock.Advance(client.cacheConfig.SearchTTL + time.Second)
+	if _, err := client.Search.KeywordSearch(ctx, opts); err != nil {
+		t.Fatalf("KeywordSearch: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected a refetch after the fake TTL elapsed, got %d requests", requests)
+	}
+}
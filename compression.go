@@ -0,0 +1,77 @@
+package mouser
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is the Accept-Encoding header sent with WithCompression.
+const acceptEncoding = "gzip, deflate"
+
+// decompressBody replaces resp.Body with a decoding reader when the response
+// has a gzip or deflate Content-Encoding. Go's transport only decompresses
+// transparently when it added Accept-Encoding itself, so responses to
+// requests that set the header, or from custom transports, arrive encoded.
+func decompressBody(resp *http.Response) error {
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+
+	var reader io.ReadCloser
+	switch encoding {
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("mouser: failed to decompress response: %w", err)
+		}
+		reader = gz
+	case "deflate":
+		r, err := newDeflateReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("mouser: failed to decompress response: %w", err)
+		}
+		reader = r
+	default:
+		return nil
+	}
+
+	resp.Body = &decompressedBody{ReadCloser: reader, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// newDeflateReader reads an HTTP "deflate" body. RFC 9110 defines it as
+// zlib-wrapped, but some servers send raw DEFLATE data, so the zlib header
+// is checked before choosing a decoder.
+func newDeflateReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(br)
+	}
+	return flate.NewReader(br), nil
+}
+
+// decompressedBody closes both the decoder and the underlying body.
+type decompressedBody struct {
+	io.ReadCloser
+	body io.Closer
+}
+
+func (b *decompressedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if cerr := b.body.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,137 @@
+package mouser
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const compressionTestBody = `{"Errors":[],"SearchResults":{"NumberOfResult":1,"Parts":[{"MouserPartNumber":"595-NE555DR"}]}}`
+
+func compressBody(t *testing.T, encoding string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+		if err != nil {
+			t.Fatalf("flate.NewWriter: %v", err)
+		}
+		w = fw
+	default:
+		return []byte(compressionTestBody)
+	}
+	if _, err := w.Write([]byte(compressionTestBody)); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// TestWithCompression tests that compressed responses are requested and
+// decoded for each supported encoding.
+func TestWithCompression(t *testing.T) {
+	tests := []struct {
+		encoding string
+		header   string
+	}{
+		{"gzip", "gzip"},
+		{"deflate", "deflate"},
+		{"raw-deflate", "deflate"},
+		{"identity", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			var acceptEncoding string
+			body := compressBody(t, tt.encoding)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				acceptEncoding = r.Header.Get("Accept-Encoding")
+				w.Header().Set("Content-Type", "application/json")
+				if tt.header != "" {
+					w.Header().Set("Content-Encoding", tt.header)
+				}
+				_, _ = w.Write(body)
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test-key", WithBaseURL(server.URL), WithoutRetry(), WithoutCache(), WithCompression())
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			defer client.Close()
+
+			result, err := client.Search.KeywordSearch(context.Background(), SearchOptions{Keyword: "NE555"})
+			if err != nil {
+				t.Fatalf("KeywordSearch: %v", err)
+			}
+			if len(result.Parts) != 1 || result.Parts[0].MouserPartNumber != "595-NE555DR" {
+				t.Errorf("unexpected result %+v", result)
+			}
+			if acceptEncoding != "gzip, deflate" {
+				t.Errorf("expected Accept-Encoding %q, got %q", "gzip, deflate", acceptEncoding)
+			}
+		})
+	}
+}
+
+// TestWithCompressionStreaming tests that streamed decoding reads the
+// decompressed body.
+func TestWithCompressionStreaming(t *testing.T) {
+	body := compressBody(t, "gzip")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(server.URL), WithoutRetry(), WithoutCache(), WithCompression(), WithStreamingDecode())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	result, err := client.Search.KeywordSearch(context.Background(), SearchOptions{Keyword: "NE555"})
+	if err != nil {
+		t.Fatalf("KeywordSearch: %v", err)
+	}
+	if len(result.Parts) != 1 {
+		t.Errorf("expected 1 part, got %d", len(result.Parts))
+	}
+}
+
+// TestWithCompressionCorruptBody tests that an undecodable gzip body fails
+// with a decompression error.
+func TestWithCompressionCorruptBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write([]byte("not gzip"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(server.URL), WithoutRetry(), WithoutCache(), WithCompression())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.Search.KeywordSearch(context.Background(), SearchOptions{Keyword: "NE555"})
+	if err == nil || !strings.Contains(err.Error(), "failed to decompress response") {
+		t.Errorf("expected decompression error, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package mouser
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// waitForRateLimitKey marks a context whose requests should block on the
+// rate limiter instead of failing fast.
+type waitForRateLimitKey struct{}
+
+// withRateLimitWait returns a context whose requests wait for a rate limit
+// token. Bulk helpers use it so that a large batch is paced rather than
+// failing partway through with a *RateLimitError.
+func withRateLimitWait(ctx context.Context) context.Context {
+	return context.WithValue(ctx, waitForRateLimitKey{}, true)
+}
+
+// waitsForRateLimit reports whether ctx was created by withRateLimitWait.
+func waitsForRateLimit(ctx context.Context) bool {
+	wait, _ := ctx.Value(waitForRateLimitKey{}).(bool)
+	return wait
+}
+
+// noRetryKey marks a context whose requests must be attempted only once.
+type noRetryKey struct{}
+
+// withoutRetries returns a context whose requests are never retried,
+// regardless of the client's RetryConfig. It guards requests that are not
+// safe to repeat, such as submitting an order.
+func withoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// retriesDisabled reports whether ctx was created by withoutRetries.
+func retriesDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(noRetryKey{}).(bool)
+	return disabled
+}
+
+// noCacheReadKey marks a context whose requests must skip cached responses.
+type noCacheReadKey struct{}
+
+// withoutCacheRead returns a context whose requests always go to the API.
+// Fresh responses are still written to the cache, so polling helpers keep
+// it warm for other callers.
+func withoutCacheRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheReadKey{}, true)
+}
+
+// cacheReadDisabled reports whether ctx was created by withoutCacheRead.
+func cacheReadDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(noCacheReadKey{}).(bool)
+	return disabled
+}
+
+// callStatsKey holds the *callStats a call collects through withCallStats.
+type callStatsKey struct{}
+
+// callStats records the API requests made on behalf of one method call.
+type callStats struct {
+	sent      int
+	requests  int
+	rateLimit RateLimitStats
+	captured  bool
+}
+
+// withCallStats returns a context whose requests are recorded in the
+// returned callStats. The requests of one call are sequential, so it needs
+// no locking.
+func withCallStats(ctx context.Context) (context.Context, *callStats) {
+	stats := &callStats{}
+	return context.WithValue(ctx, callStatsKey{}, stats), stats
+}
+
+// callStatsFrom returns the callStats attached by withCallStats, or nil.
+func callStatsFrom(ctx context.Context) *callStats {
+	stats, _ := ctx.Value(callStatsKey{}).(*callStats)
+	return stats
+}
+
+// requestIDKey holds the correlation ID set by WithRequestID.
+type requestIDKey struct{}
+
+// WithRequestID returns a context whose requests carry the correlation ID
+// id. It is stored on any *MouserError the requests return, added to log
+// records, and sent in the header named by WithRequestIDHeader, if set.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// requestIDFrom returns the correlation ID set by WithRequestID, or "".
+func requestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// logFieldsKey holds the fields added by ContextWithLogFields.
+type logFieldsKey struct{}
+
+// ContextWithLogFields returns a context whose requests are logged with the
+// given fields, such as a user or BOM ID, in addition to the client's own.
+// Fields accumulate across calls; a later value replaces an earlier one with
+// the same key. They only appear if the client was created WithLogger.
+func ContextWithLogFields(ctx context.Context, fields map[string]any) context.Context {
+	merged := make(map[string]any, len(fields))
+	if existing, ok := ctx.Value(logFieldsKey{}).(map[string]any); ok {
+		for k, v := range existing {
+			merged[k] = v
+		}
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, logFieldsKey{}, merged)
+}
+
+// logFields returns the fields added to ctx by ContextWithLogFields as slog
+// attributes, sorted by key.
+func logFields(ctx context.Context) []slog.Attr {
+	fields, _ := ctx.Value(logFieldsKey{}).(map[string]any)
+	attrs := make([]slog.Attr, 0, len(fields))
+	for k, v := range fields {
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+	return attrs
+}
+
+// searchBudgetKey holds the budget set by ContextWithSearchBudget.
+type searchBudgetKey struct{}
+
+// ContextWithSearchBudget returns a context that caps the total wall-clock
+// time Search.All and Search.AllByManufacturer may spend across all pages.
+// The budget starts when the iteration starts. Once it is spent, the page
+// in flight is abandoned and the iteration returns ErrSearchBudgetExceeded;
+// every part from earlier pages has already been passed to the callback. A
+// budget <= 0 is ignored.
+func ContextWithSearchBudget(ctx context.Context, budget time.Duration) context.Context {
+	return context.WithValue(ctx, searchBudgetKey{}, budget)
+}
+
+// withSearchBudget applies the budget set by ContextWithSearchBudget, if
+// any, as a deadline whose cause is ErrSearchBudgetExceeded.
+func withSearchBudget(ctx context.Context) (context.Context, context.CancelFunc) {
+	budget, _ := ctx.Value(searchBudgetKey{}).(time.Duration)
+	if budget <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeoutCause(ctx, budget, ErrSearchBudgetExceeded)
+}
+
+// iterationErr returns the error a paging iteration reports once ctx is
+// done: ErrSearchBudgetExceeded if its search budget ran out, or ctx.Err().
+func iterationErr(ctx context.Context) error {
+	if cause := context.Cause(ctx); errors.Is(cause, ErrSearchBudgetExceeded) {
+		return cause
+	}
+	return ctx.Err()
+}
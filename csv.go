@@ -0,0 +1,170 @@
+package mouser
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DefaultPartCSVColumns are the columns SearchResult.WriteCSV writes when
+// none are given.
+var DefaultPartCSVColumns = []string{
+	"MouserPartNumber",
+	"ManufacturerPartNumber",
+	"Manufacturer",
+	"Description",
+	"AvailabilityInStock",
+	"UnitPrice",
+}
+
+// DefaultCartCSVColumns are the columns CartResponse.WriteCSV writes when
+// none are given.
+var DefaultCartCSVColumns = []string{
+	"MouserPartNumber",
+	"MfrPartNumber",
+	"Manufacturer",
+	"Description",
+	"Quantity",
+	"UnitPrice",
+	"ExtendedPrice",
+}
+
+// partCSVExtras are computed Part columns that have no matching field.
+var partCSVExtras = map[string]func(reflect.Value) string{
+	// UnitPrice is the price at the lowest price break.
+	"UnitPrice": func(v reflect.Value) string {
+		part := v.Interface().(Part)
+		if len(part.PriceBreaks) == 0 {
+			return ""
+		}
+		return part.PriceBreaks[0].Price
+	},
+}
+
+// WriteCSV writes the parts as CSV with a header row and one row per part.
+// Columns are Part field names, matched case-insensitively, plus the computed
+// "UnitPrice" (the price at the lowest price break). Without columns,
+// DefaultPartCSVColumns is used. Only scalar and []string fields can be
+// written; []string values are joined with "; ".
+func (r *SearchResult) WriteCSV(w io.Writer, columns ...string) error {
+	if len(columns) == 0 {
+		columns = DefaultPartCSVColumns
+	}
+	rows := make([]reflect.Value, len(r.Parts))
+	for i := range r.Parts {
+		rows[i] = reflect.ValueOf(r.Parts[i])
+	}
+	return writeCSV(w, reflect.TypeOf(Part{}), columns, partCSVExtras, rows)
+}
+
+// WriteCSV writes the cart as CSV with a header row and one row per line
+// item. Columns are CartOrderLine field names, matched case-insensitively.
+// Without columns, DefaultCartCSVColumns is used.
+func (r *CartResponse) WriteCSV(w io.Writer, columns ...string) error {
+	if len(columns) == 0 {
+		columns = DefaultCartCSVColumns
+	}
+	rows := make([]reflect.Value, len(r.CartItems))
+	for i := range r.CartItems {
+		rows[i] = reflect.ValueOf(r.CartItems[i])
+	}
+	return writeCSV(w, reflect.TypeOf(CartOrderLine{}), columns, nil, rows)
+}
+
+// csvColumn is a resolved CSV column.
+type csvColumn struct {
+	header string
+	value  func(reflect.Value) string
+}
+
+// writeCSV resolves columns against typ and writes the header and rows.
+func writeCSV(w io.Writer, typ reflect.Type, columns []string, extras map[string]func(reflect.Value) string, rows []reflect.Value) error {
+	resolved := make([]csvColumn, len(columns))
+	for i, name := range columns {
+		col, err := resolveCSVColumn(typ, name, extras)
+		if err != nil {
+			return err
+		}
+		resolved[i] = col
+	}
+
+	cw := csv.NewWriter(w)
+
+	record := make([]string, len(resolved))
+	for i, col := range resolved {
+		record[i] = col.header
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		for i, col := range resolved {
+			record[i] = col.value(row)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// resolveCSVColumn finds the field or computed column named name.
+func resolveCSVColumn(typ reflect.Type, name string, extras map[string]func(reflect.Value) string) (csvColumn, error) {
+	for extra, fn := range extras {
+		if strings.EqualFold(extra, name) {
+			return csvColumn{header: extra, value: fn}, nil
+		}
+	}
+
+	field, ok := typ.FieldByNameFunc(func(n string) bool { return strings.EqualFold(n, name) })
+	if !ok {
+		return csvColumn{}, fmt.Errorf("mouser: unknown CSV column %q for %s", name, typ.Name())
+	}
+
+	index := field.Index
+	switch field.Type.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Float32, reflect.Float64:
+	case reflect.Slice:
+		if field.Type.Elem().Kind() != reflect.String {
+			return csvColumn{}, fmt.Errorf("mouser: CSV column %q has unsupported type %s", name, field.Type)
+		}
+	default:
+		return csvColumn{}, fmt.Errorf("mouser: CSV column %q has unsupported type %s", name, field.Type)
+	}
+
+	return csvColumn{
+		header: field.Name,
+		value: func(v reflect.Value) string {
+			return formatCSVValue(v.FieldByIndex(index))
+		},
+	}, nil
+}
+
+// formatCSVValue formats a scalar or []string value for a CSV cell.
+func formatCSVValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Slice:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).String()
+		}
+		return strings.Join(items, "; ")
+	}
+	return ""
+}
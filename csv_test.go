@@ -0,0 +1,105 @@
+package mouser
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestSearchResultWriteCSVDefault tests the default part columns.
+func TestSearchResultWriteCSVDefault(t *testing.T) {
+	result := SearchResult{
+		NumberOfResult: 2,
+		Parts: []Part{
+			{
+				MouserPartNumber:       "595-NE555P",
+				ManufacturerPartNumber: "NE555P",
+				Manufacturer:           "Texas Instruments",
+				Description:            "Timer, \"precision\"",
+				AvailabilityInStock:    "1200",
+				PriceBreaks:            []PriceBreak{{Quantity: 1, Price: "$0.45"}, {Quantity: 10, Price: "$0.38"}},
+			},
+			{MouserPartNumber: "NOPRICE-001"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := result.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d records", len(records))
+	}
+	if strings.Join(records[0], ",") != strings.Join(DefaultPartCSVColumns, ",") {
+		t.Errorf("unexpected header: %v", records[0])
+	}
+	want := []string{"595-NE555P", "NE555P", "Texas Instruments", "Timer, \"precision\"", "1200", "$0.45"}
+	if strings.Join(records[1], "|") != strings.Join(want, "|") {
+		t.Errorf("row 1 = %v, want %v", records[1], want)
+	}
+	if records[2][0] != "NOPRICE-001" || records[2][5] != "" {
+		t.Errorf("unexpected row 2: %v", records[2])
+	}
+}
+
+// TestSearchResultWriteCSVColumns tests selecting columns by field name.
+func TestSearchResultWriteCSVColumns(t *testing.T) {
+	result := SearchResult{Parts: []Part{{
+		MouserPartNumber: "595-NE555P",
+		Reeling:          true,
+		MultiSimBlue:     2,
+		REACH_SVHC:       []string{"Lead", "Cadmium"},
+	}}}
+
+	var buf bytes.Buffer
+	if err := result.WriteCSV(&buf, "mouserpartnumber", "Reeling", "MultiSimBlue", "REACH_SVHC"); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+
+	want := "MouserPartNumber,Reeling,MultiSimBlue,REACH_SVHC\n595-NE555P,true,2,Lead; Cadmium\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+// TestSearchResultWriteCSVInvalidColumn tests that unknown and unsupported columns are rejected.
+func TestSearchResultWriteCSVInvalidColumn(t *testing.T) {
+	result := SearchResult{Parts: []Part{{MouserPartNumber: "595-NE555P"}}}
+
+	var buf bytes.Buffer
+	if err := result.WriteCSV(&buf, "NoSuchField"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+	if err := result.WriteCSV(&buf, "PriceBreaks"); err == nil {
+		t.Error("expected error for unsupported column type")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing written on error, got %q", buf.String())
+	}
+}
+
+// TestCartResponseWriteCSV tests the default cart columns.
+func TestCartResponseWriteCSV(t *testing.T) {
+	var cart CartResponse
+	if err := json.Unmarshal([]byte(cartSuccessResponse()), &cart); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := cart.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+
+	want := "MouserPartNumber,MfrPartNumber,Manufacturer,Description,Quantity,UnitPrice,ExtendedPrice\n" +
+		"TEST-001,MFR-001,TestMfr,Test Part,10,1.5,15\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
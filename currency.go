@@ -0,0 +1,56 @@
+package mouser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CurrencyConverter converts money amounts between ISO 4217 currency codes.
+// Implementations supply their own exchange rates; see WithCurrencyConverter.
+type CurrencyConverter interface {
+	Convert(amount float64, from, to string) (float64, error)
+}
+
+// TotalInCurrency returns the sum of each part's unit price at its smallest
+// price break, converted into the target currency with conv. Parts without
+// price breaks are skipped, and prices already in target need no
+// conversion, so conv may be nil if all prices are in target. It fails if a
+// price cannot be parsed, or if a conversion is needed and fails or conv is
+// nil.
+func (r *SearchResult) TotalInCurrency(target string, conv CurrencyConverter) (float64, error) {
+	var total float64
+	for _, part := range r.Parts {
+		if len(part.PriceBreaks) == 0 {
+			continue
+		}
+		first := part.PriceBreaks[0]
+		for _, pb := range part.PriceBreaks[1:] {
+			if pb.Quantity < first.Quantity {
+				first = pb
+			}
+		}
+
+		price, ok := parsePrice(first.Price)
+		if !ok {
+			return 0, fmt.Errorf("mouser: %s has unparseable price %q", part.MouserPartNumber, first.Price)
+		}
+		if !strings.EqualFold(first.Currency, target) {
+			if conv == nil {
+				return 0, fmt.Errorf("mouser: no currency converter to convert %s to %s", first.Currency, target)
+			}
+			converted, err := conv.Convert(price, first.Currency, target)
+			if err != nil {
+				return 0, fmt.Errorf("mouser: converting %s price of %s: %w", first.Currency, part.MouserPartNumber, err)
+			}
+			price = converted
+		}
+		total += price
+	}
+	return total, nil
+}
+
+// TotalInCurrency is SearchResult.TotalInCurrency with the converter set by
+// WithCurrencyConverter.
+func (s *SearchService) TotalInCurrency(result *SearchResult, target string) (float64, error) {
+	return result.TotalInCurrency(target, s.client.currencyConverter)
+}
@@ -0,0 +1,91 @@
+package mouser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fixedRates converts with fixed rates into USD.
+type fixedRates map[string]float64
+
+func (f fixedRates) Convert(amount float64, from, to string) (float64, error) {
+	rate, ok := f[from]
+	if !ok || to != "USD" {
+		return 0, fmt.Errorf("no rate for %s to %s", from, to)
+	}
+	return amount * rate, nil
+}
+
+// TestTotalInCurrency tests that search results sum normalized prices with
+// the client's converter or one passed in.
+func TestTotalInCurrency(t *testing.T) {
+	parts := []Part{
+		{MouserPartNumber: "A", PriceBreaks: []PriceBreak{{Quantity: 10, Price: "$0.50", Currency: "USD"}, {Quantity: 1, Price: "$1.00", Currency: "USD"}}},
+		{MouserPartNumber: "B", PriceBreaks: []PriceBreak{{Quantity: 1, Price: "2,00 €", Currency: "EUR"}}},
+		{MouserPartNumber: "C"},
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(searchResponse{SearchResults: SearchResult{NumberOfResult: len(parts), Parts: parts}})
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(server.URL), WithoutRetry(), WithoutCache(), WithCurrencyConverter(fixedRates{"EUR": 1.1}))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	result, err := client.Search.KeywordSearch(context.Background(), SearchOptions{Keyword: "timer"})
+	if err != nil {
+		t.Fatalf("KeywordSearch: %v", err)
+	}
+	total, err := client.Search.TotalInCurrency(result, "USD")
+	if err != nil {
+		t.Fatalf("TotalInCurrency: %v", err)
+	}
+	if math.Abs(total-3.2) > 1e-9 {
+		t.Errorf("expected total 3.2, got %v", total)
+	}
+
+	// A result reloaded from JSON converts the same way.
+	data, _ := json.Marshal(result)
+	var reloaded SearchResult
+	if err := json.Unmarshal(data, &reloaded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if total, err := reloaded.TotalInCurrency("USD", fixedRates{"EUR": 1.1}); err != nil || math.Abs(total-3.2) > 1e-9 {
+		t.Errorf("reloaded TotalInCurrency = %v, %v; want 3.2", total, err)
+	}
+
+	if _, err := client.Search.TotalInCurrency(result, "GBP"); err == nil {
+		t.Error("expected a conversion error for GBP")
+	}
+}
+
+// TestTotalInCurrencyNoConverter tests that a converter is only required
+// when currencies differ.
+func TestTotalInCurrencyNoConverter(t *testing.T) {
+	result := &SearchResult{Parts: []Part{
+		{MouserPartNumber: "A", PriceBreaks: []PriceBreak{{Quantity: 1, Price: "$1.25", Currency: "USD"}}},
+	}}
+	if total, err := result.TotalInCurrency("usd", nil); err != nil || total != 1.25 {
+		t.Errorf("TotalInCurrency(usd) = %v, %v; want 1.25", total, err)
+	}
+
+	result.Parts = append(result.Parts, Part{MouserPartNumber: "B", PriceBreaks: []PriceBreak{{Quantity: 1, Price: "2,00 €", Currency: "EUR"}}})
+	if _, err := result.TotalInCurrency("USD", nil); err == nil {
+		t.Error("expected an error without a converter")
+	}
+
+	result.Parts = []Part{{MouserPartNumber: "C", PriceBreaks: []PriceBreak{{Quantity: 1, Price: "n/a", Currency: "USD"}}}}
+	if _, err := result.TotalInCurrency("USD", nil); err == nil {
+		t.Error("expected a parse error")
+	}
+}
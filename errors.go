@@ -3,6 +3,10 @@ package mouser
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -22,6 +26,10 @@ var (
 	// ErrNotFound is returned when a part is not found.
 	ErrNotFound = errors.New("mouser: part not found")
 
+	// ErrAmbiguousPart is returned by ProductDetail when a part number
+	// matches more than one part.
+	ErrAmbiguousPart = errors.New("mouser: part number is ambiguous")
+
 	// ErrUnauthorized is returned when the API key is invalid or missing.
 	ErrUnauthorized = errors.New("mouser: unauthorized")
 
@@ -33,6 +41,54 @@ var (
 
 	// ErrServerError is returned when the server returns a 5xx error.
 	ErrServerError = errors.New("mouser: server error")
+
+	// ErrCartNotReady is returned when a cart fails the pre-order checks.
+	ErrCartNotReady = errors.New("mouser: cart not ready to order")
+
+	// ErrTooManyParts is returned when a part number search contains more
+	// pipe-separated part numbers than the API accepts.
+	ErrTooManyParts = errors.New("mouser: too many part numbers")
+
+	// ErrNoResults is returned by searches with ErrorOnEmpty set when nothing matches.
+	ErrNoResults = errors.New("mouser: no search results")
+
+	// ErrCartLineErrors is returned with WithStrictCartLines when a cart
+	// request succeeded but some of its lines carry errors.
+	ErrCartLineErrors = errors.New("mouser: cart lines have errors")
+
+	// ErrOrderWarnings is returned when an order was created but the API also
+	// reported errors. The order response is returned alongside it.
+	ErrOrderWarnings = errors.New("mouser: order created with warnings")
+
+	// ErrSubmitStatusUnknown is returned when an order submission was sent
+	// but no definitive answer came back, so the order may have been placed.
+	ErrSubmitStatusUnknown = errors.New("mouser: order submission status unknown")
+
+	// ErrDecodeTimeout is returned when decoding a response takes longer
+	// than the limit set with WithDecodeTimeout.
+	ErrDecodeTimeout = errors.New("mouser: response decode timed out")
+
+	// ErrCircuitOpen is returned when the circuit breaker is rejecting
+	// requests after repeated failures (see WithCircuitBreaker).
+	ErrCircuitOpen = errors.New("mouser: circuit breaker open")
+
+	// ErrSearchBudgetExceeded is returned by Search.All and
+	// Search.AllByManufacturer when the budget set with
+	// ContextWithSearchBudget runs out.
+	ErrSearchBudgetExceeded = errors.New("mouser: search time budget exceeded")
+
+	// ErrNoRecording is returned by ReplayTransport for a request that has
+	// no recording.
+	ErrNoRecording = errors.New("mouser: no recorded response")
+
+	// ErrConflictingOptions is returned by NewClient when two ClientOptions
+	// cannot be combined, such as WithProxy or WithTLSConfig and
+	// WithHTTPClient.
+	ErrConflictingOptions = errors.New("mouser: conflicting client options")
+
+	// ErrBelowMinimumOrder is returned when Mouser rejects an order whose
+	// value is below the minimum for the region.
+	ErrBelowMinimumOrder = errors.New("mouser: order below minimum value")
 )
 
 // MouserError represents a structured error from the Mouser API.
@@ -44,6 +100,14 @@ type MouserError struct {
 	Endpoint    string     // API endpoint that failed
 	RetryAfter  int        // Seconds to wait before retrying (from Retry-After header)
 	IsRetryable bool       // Whether this error is retryable
+	RequestID   string     // Correlation ID from WithRequestID, if any
+
+	// RawBody is the response body as received, truncated to the client's
+	// maximum raw body size (see WithMaxRawBodySize).
+	RawBody []byte
+
+	// RawBodyTruncated reports whether RawBody was truncated.
+	RawBodyTruncated bool
 }
 
 // Error implements the error interface.
@@ -71,6 +135,10 @@ func (e *MouserError) Unwrap() error {
 		if e.StatusCode >= 500 {
 			return ErrServerError
 		}
+		if e.StatusCode >= 200 && e.StatusCode < 300 {
+			// A successful status with an error means the body was unusable.
+			return ErrInvalidResponse
+		}
 		return nil
 	}
 }
@@ -100,6 +168,8 @@ type RateLimitError struct {
 	Remaining int       // Remaining requests (typically 0)
 	ResetAt   time.Time // When the limit resets
 	Type      string    // "minute" or "day"
+
+	clock Clock // The limiter's clock, which ResetAt is on
 }
 
 // Error implements the error interface.
@@ -116,6 +186,266 @@ func (e *RateLimitError) Unwrap() error {
 	return ErrRateLimitExceeded
 }
 
+// TooManyPartsError is returned when a part number search exceeds MaxPartNumbers.
+type TooManyPartsError struct {
+	Count int // Number of part numbers in the request
+	Limit int // Maximum number of part numbers allowed
+}
+
+// Error implements the error interface.
+func (e *TooManyPartsError) Error() string {
+	return fmt.Sprintf("mouser: too many part numbers (%d, max %d)", e.Count, e.Limit)
+}
+
+// Unwrap returns the underlying sentinel error.
+func (e *TooManyPartsError) Unwrap() error {
+	return ErrTooManyParts
+}
+
+// OrderWarningsError is returned with a non-nil *OrderResponse when Mouser
+// assigned an order number but also reported errors. The order may have been
+// placed, so callers must not treat it as a failed order.
+type OrderWarningsError struct {
+	OrderNumber string    // The order number Mouser assigned
+	Warnings    APIErrors // The errors reported alongside the order
+}
+
+// Error implements the error interface.
+func (e *OrderWarningsError) Error() string {
+	return fmt.Sprintf("mouser: order %s created with warnings: %s", e.OrderNumber, e.Warnings.Error())
+}
+
+// Unwrap returns ErrOrderWarnings and the underlying API errors.
+func (e *OrderWarningsError) Unwrap() []error {
+	return []error{ErrOrderWarnings, e.Warnings}
+}
+
+// SubmitStatusUnknownError is returned when an order submission reached the
+// network but failed without a definitive answer from Mouser, for example
+// because the context was cancelled or timed out mid-flight, the connection
+// dropped, or the server returned a 5xx or an unreadable response. The order
+// may or may not have been placed; do not resubmit before checking with
+// OrderService.ReconcileSubmit. It unwraps to ErrSubmitStatusUnknown and to
+// the underlying error, so errors.Is(err, context.Canceled) still works.
+type SubmitStatusUnknownError struct {
+	CartKey     string    // The cart that was being ordered, if any
+	SubmittedAt time.Time // When the submission was started
+	Err         error     // The underlying error
+}
+
+// Error implements the error interface.
+func (e *SubmitStatusUnknownError) Error() string {
+	return fmt.Sprintf("mouser: order submission status unknown: %v", e.Err)
+}
+
+// Unwrap returns ErrSubmitStatusUnknown and the underlying error.
+func (e *SubmitStatusUnknownError) Unwrap() []error {
+	return []error{ErrSubmitStatusUnknown, e.Err}
+}
+
+// CartLineErrorsError is returned with a non-nil *CartResponse, when the
+// client was created with WithStrictCartLines, if a cart request succeeded
+// but some lines failed. The rest of the cart was updated.
+type CartLineErrorsError struct {
+	CartKey    string                // The cart that was updated
+	LineErrors map[string][]APIError // Errors keyed by MouserPartNumber
+}
+
+// Error implements the error interface.
+func (e *CartLineErrorsError) Error() string {
+	parts := make([]string, 0, len(e.LineErrors))
+	for pn := range e.LineErrors {
+		parts = append(parts, pn)
+	}
+	sort.Strings(parts)
+
+	problems := make([]string, len(parts))
+	for i, pn := range parts {
+		problems[i] = fmt.Sprintf("%s: %s", pn, APIErrors(e.LineErrors[pn]).Error())
+	}
+	return fmt.Sprintf("mouser: cart %s has %d failed line(s): %s", e.CartKey, len(parts), strings.Join(problems, "; "))
+}
+
+// Unwrap returns ErrCartLineErrors.
+func (e *CartLineErrorsError) Unwrap() error {
+	return ErrCartLineErrors
+}
+
+// CartInsertChunkError is returned with a non-nil *CartResponse when a
+// chunked Cart.InsertItems call fails after earlier chunks were inserted.
+// The response is the cart after the last chunk that succeeded.
+type CartInsertChunkError struct {
+	CartKey string // The partially filled cart
+	Chunk   int    // The 1-based chunk that failed
+	Chunks  int    // The total number of chunks
+	Err     error  // The underlying error
+}
+
+// Error implements the error interface.
+func (e *CartInsertChunkError) Error() string {
+	return fmt.Sprintf("mouser: cart insert chunk %d of %d failed for cart %s: %v", e.Chunk, e.Chunks, e.CartKey, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CartInsertChunkError) Unwrap() error {
+	return e.Err
+}
+
+// PartialSearchError is returned with the merged result when one of the
+// searches SmartSearch combines fails. The result then only holds the
+// other search's parts.
+type PartialSearchError struct {
+	Search string // The search that failed: "keyword" or "part number"
+	Err    error  // The underlying error
+}
+
+// Error implements the error interface.
+func (e *PartialSearchError) Error() string {
+	return fmt.Sprintf("mouser: %s search failed, result is partial: %v", e.Search, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PartialSearchError) Unwrap() error {
+	return e.Err
+}
+
+// BelowMinimumOrderError is returned when Mouser rejects an order for being
+// below the regional minimum order value, so callers can prompt the user to
+// add more items.
+type BelowMinimumOrderError struct {
+	// Minimum is the minimum order value parsed from the error message, or 0
+	// if the message did not state one.
+	Minimum float64
+
+	// CurrencyCode is the ISO currency code stated next to Minimum, if any.
+	CurrencyCode string
+
+	// Message is the message of the API error that reported the rejection.
+	Message string
+
+	// Err is the underlying APIErrors or *MouserError.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *BelowMinimumOrderError) Error() string {
+	if e.Minimum > 0 {
+		minimum := strconv.FormatFloat(e.Minimum, 'f', 2, 64)
+		if e.CurrencyCode != "" {
+			minimum += " " + e.CurrencyCode
+		}
+		return fmt.Sprintf("mouser: order below minimum value of %s: %s", minimum, e.Message)
+	}
+	return "mouser: order below minimum value: " + e.Message
+}
+
+// Unwrap returns ErrBelowMinimumOrder and the underlying error.
+func (e *BelowMinimumOrderError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{ErrBelowMinimumOrder, e.Err}
+	}
+	return []error{ErrBelowMinimumOrder}
+}
+
+// minimumOrderAmount matches an amount with an optional currency symbol or
+// ISO code on either side, e.g. "$50.00", "EUR 50,00", or "50.00 GBP".
+var minimumOrderAmount = regexp.MustCompile(`(?:\b([A-Z]{3})\s*|[$€£¥]\s*)?(\d[\d.,]*)(?:\s*([A-Z]{3})\b)?`)
+
+// belowMinimumOrder returns a *BelowMinimumOrderError if any of errs reports
+// a minimum order value rejection, or nil. Mouser has no dedicated error
+// code for it, so both the code and the message are checked.
+func belowMinimumOrder(errs []APIError, err error) *BelowMinimumOrderError {
+	for _, apiErr := range errs {
+		if !isMinimumOrderValue(apiErr) {
+			continue
+		}
+
+		minErr := &BelowMinimumOrderError{Message: apiErr.Message, Err: err}
+		for _, m := range minimumOrderAmount.FindAllStringSubmatch(apiErr.Message, -1) {
+			if amount, ok := parseAmount(m[2]); ok && amount > 0 {
+				minErr.Minimum = amount
+				minErr.CurrencyCode = m[1]
+				if minErr.CurrencyCode == "" {
+					minErr.CurrencyCode = m[3]
+				}
+				break
+			}
+		}
+		return minErr
+	}
+	return nil
+}
+
+// isMinimumOrderValue reports whether apiErr is about the minimum order
+// value rather than, say, a part's minimum order quantity: besides a
+// minimum, the code or message must mention a value, amount, or total, or
+// the message must quote a price.
+func isMinimumOrderValue(apiErr APIError) bool {
+	code := strings.ToLower(apiErr.Code)
+	msg := strings.ToLower(apiErr.Message)
+	if !strings.Contains(code, "minimum") && !strings.Contains(msg, "minimum order") {
+		return false
+	}
+	for _, s := range []string{code, msg} {
+		if strings.Contains(s, "quantity") || strings.Contains(s, "qty") {
+			return false
+		}
+	}
+	for _, s := range []string{code, msg} {
+		if strings.Contains(s, "value") || strings.Contains(s, "amount") || strings.Contains(s, "total") {
+			return true
+		}
+	}
+	for _, m := range minimumOrderAmount.FindAllStringSubmatch(apiErr.Message, -1) {
+		if m[1] != "" || m[3] != "" || strings.ContainsAny(m[0], "$€£¥") {
+			return true
+		}
+	}
+	return false
+}
+
+// UnresolvedPartsError lists part numbers from a BOM or bulk mapping that
+// could not be matched to a Mouser part. It unwraps to ErrNotFound and to any search errors that
+// prevented resolution.
+type UnresolvedPartsError struct {
+	PartNumbers []string // Part numbers that were not resolved, sorted
+	Err         error    // Search errors encountered while resolving, if any
+}
+
+// Error implements the error interface.
+func (e *UnresolvedPartsError) Error() string {
+	msg := fmt.Sprintf("mouser: %d part numbers not resolved: %s", len(e.PartNumbers), strings.Join(e.PartNumbers, ", "))
+	if e.Err != nil {
+		msg += " (" + e.Err.Error() + ")"
+	}
+	return msg
+}
+
+// Unwrap returns ErrNotFound and any underlying search error.
+func (e *UnresolvedPartsError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{ErrNotFound, e.Err}
+	}
+	return []error{ErrNotFound}
+}
+
+// AmbiguousPartError is returned by ProductDetail when a part number matches
+// several parts. It unwraps to ErrAmbiguousPart.
+type AmbiguousPartError struct {
+	PartNumber string
+	Candidates []string // Mouser part numbers of the matching parts
+}
+
+// Error implements the error interface.
+func (e *AmbiguousPartError) Error() string {
+	return fmt.Sprintf("mouser: part number %s matches %d parts: %s", e.PartNumber, len(e.Candidates), strings.Join(e.Candidates, ", "))
+}
+
+// Unwrap returns ErrAmbiguousPart.
+func (e *AmbiguousPartError) Unwrap() error {
+	return ErrAmbiguousPart
+}
+
 // APIErrors represents a collection of API errors.
 type APIErrors []APIError
 
@@ -129,3 +459,59 @@ func (e APIErrors) Error() string {
 	}
 	return fmt.Sprintf("mouser: %d API errors: %s (and %d more)", len(e), e[0].Message, len(e)-1)
 }
+
+// ByCode returns the first error with the given Mouser error code,
+// compared case-insensitively.
+func (e APIErrors) ByCode(code string) (APIError, bool) {
+	for _, apiErr := range e {
+		if strings.EqualFold(apiErr.Code, code) {
+			return apiErr, true
+		}
+	}
+	return APIError{}, false
+}
+
+// Codes returns the distinct error codes in order of first appearance.
+// Errors without a code are skipped.
+func (e APIErrors) Codes() []string {
+	var codes []string
+	seen := make(map[string]bool)
+	for _, apiErr := range e {
+		if apiErr.Code == "" || seen[apiErr.Code] {
+			continue
+		}
+		seen[apiErr.Code] = true
+		codes = append(codes, apiErr.Code)
+	}
+	return codes
+}
+
+// Is reports whether any error in the collection has a Mouser error code
+// known to correspond to target, so that for example
+// errors.Is(err, ErrInvalidRequest) holds for an "InvalidKeyword" error.
+func (e APIErrors) Is(target error) bool {
+	for _, apiErr := range e {
+		for _, sentinel := range apiErrorCodeSentinels[strings.ToLower(apiErr.Code)] {
+			if sentinel == target {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// apiErrorCodeSentinels maps lowercased Mouser error codes to the sentinel
+// errors they satisfy.
+var apiErrorCodeSentinels = map[string][]error{
+	"invalid":            {ErrInvalidRequest},
+	"required":           {ErrInvalidRequest},
+	"invalidcharacters":  {ErrInvalidRequest},
+	"invalidkeyword":     {ErrInvalidRequest},
+	"invalidpartnumber":  {ErrInvalidRequest},
+	"invalidcartkey":     {ErrInvalidRequest},
+	"toomanypartnumbers": {ErrInvalidRequest, ErrTooManyParts},
+	"notfound":           {ErrNotFound},
+	"partnotfound":       {ErrNotFound},
+	"invalidapikey":      {ErrUnauthorized},
+	"unauthorized":       {ErrUnauthorized},
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -209,6 +210,49 @@ func TestAPIErrorsEmpty(t *testing.T) {
 	}
 }
 
+// TestAPIErrorsByCode tests looking up errors by Mouser error code.
+func TestAPIErrorsByCode(t *testing.T) {
+	errs := APIErrors{
+		{Code: "InvalidKeyword", Message: "Keyword is invalid"},
+		{Code: "TooManyPartNumbers", Message: "Too many part numbers"},
+		{Code: "InvalidKeyword", Message: "Duplicate"},
+		{Message: "No code"},
+	}
+
+	apiErr, ok := errs.ByCode("toomanypartnumbers")
+	if !ok || apiErr.Message != "Too many part numbers" {
+		t.Errorf("ByCode = (%+v, %v), want TooManyPartNumbers error", apiErr, ok)
+	}
+	if _, ok := errs.ByCode("Missing"); ok {
+		t.Error("expected missing code to report false")
+	}
+
+	codes := errs.Codes()
+	if len(codes) != 2 || codes[0] != "InvalidKeyword" || codes[1] != "TooManyPartNumbers" {
+		t.Errorf("Codes() = %v, want [InvalidKeyword TooManyPartNumbers]", codes)
+	}
+}
+
+// TestAPIErrorsIs tests errors.Is support for known Mouser error codes.
+func TestAPIErrorsIs(t *testing.T) {
+	var err error = fmt.Errorf("search: %w", APIErrors{{Code: "TooManyPartNumbers", Message: "Too many"}})
+
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Error("expected errors.Is(err, ErrInvalidRequest)")
+	}
+	if !errors.Is(err, ErrTooManyParts) {
+		t.Error("expected errors.Is(err, ErrTooManyParts)")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("did not expect errors.Is(err, ErrNotFound)")
+	}
+
+	err = APIErrors{{Code: "SomethingNew", Message: "Unknown"}}
+	if errors.Is(err, ErrInvalidRequest) {
+		t.Error("did not expect unknown code to match ErrInvalidRequest")
+	}
+}
+
 // TestErrorVariables tests that error variables are distinct.
 func TestErrorVariables(t *testing.T) {
 	errs := []error{
@@ -221,6 +265,12 @@ func TestErrorVariables(t *testing.T) {
 		ErrForbidden,
 		ErrInvalidRequest,
 		ErrServerError,
+		ErrCartNotReady,
+		ErrTooManyParts,
+		ErrNoResults,
+		ErrOrderWarnings,
+		ErrDecodeTimeout,
+		ErrCircuitOpen,
 	}
 
 	for i, err1 := range errs {
@@ -574,3 +624,41 @@ func TestContextTimeoutError(t *testing.T) {
 		t.Logf("Context error type: %T, value: %v", err, err)
 	}
 }
+
+// TestBelowMinimumOrder tests recognition of minimum order value rejections.
+func TestBelowMinimumOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		errs     []APIError
+		matched  bool
+		minimum  float64
+		currency string
+	}{
+		{"symbol", []APIError{{Message: "Minimum order value is $50.00"}}, true, 50, ""},
+		{"trailing code", []APIError{{Message: "Orders below the minimum order value of 1,250.00 GBP cannot be placed"}}, true, 1250, "GBP"},
+		{"code only", []APIError{{Code: "MinimumOrderValue", Message: "Order rejected"}}, true, 0, ""},
+		{"unrelated", []APIError{{Code: "Invalid", Message: "Invalid cart key"}}, false, 0, ""},
+		{"currency only", []APIError{{Message: "Below minimum order: €25,00"}}, true, 25, ""},
+		{"order quantity", []APIError{{Message: "Minimum order quantity is 2500"}}, false, 0, ""},
+		{"order quantity code", []APIError{{Code: "MinimumOrderQuantity", Message: "Quantity below minimum"}}, false, 0, ""},
+		{"bare minimum order", []APIError{{Message: "Minimum order is 2500"}}, false, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minErr := belowMinimumOrder(tt.errs, APIErrors(tt.errs))
+			if (minErr != nil) != tt.matched {
+				t.Fatalf("expected matched=%v, got %v", tt.matched, minErr)
+			}
+			if minErr == nil {
+				return
+			}
+			if minErr.Minimum != tt.minimum || minErr.CurrencyCode != tt.currency {
+				t.Errorf("expected %v %q, got %v %q", tt.minimum, tt.currency, minErr.Minimum, minErr.CurrencyCode)
+			}
+			if !errors.Is(minErr, ErrBelowMinimumOrder) {
+				t.Error("expected errors.Is(err, ErrBelowMinimumOrder)")
+			}
+		})
+	}
+}
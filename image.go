@@ -0,0 +1,106 @@
+package mouser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultImageTimeout bounds an image download made with
+	// Search.DownloadImage, including streaming the body.
+	DefaultImageTimeout = 30 * time.Second
+
+	// imageBaseURL resolves ImagePath values that are relative to the
+	// Mouser website rather than absolute URLs.
+	imageBaseURL = "https://www.mouser.com/"
+)
+
+// DownloadImage fetches the image at part.ImagePath and streams it to w,
+// returning the response's Content-Type. It uses the client's HTTP client,
+// so proxy and TLS settings apply, and sends the client's User-Agent but
+// never the API key. Headers set with WithDefaultHeaders, which may carry
+// credentials meant for the API, are only sent if the image is on the same
+// host as the client's base URL. Images are not API calls and do
+// not count against the rate limit, but they do take a WithMaxConcurrency
+// slot. The download is bounded by DefaultImageTimeout.
+//
+// A part without an ImagePath yields ErrNotFound, as does a 404 from the
+// image host; other failed responses are returned as *MouserError.
+func (s *SearchService) DownloadImage(ctx context.Context, part Part, w io.Writer) (string, error) {
+	c := s.client
+
+	imageURL, err := resolveImageURL(part.ImagePath)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultImageTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("mouser: failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if sameHost(imageURL, c.baseURL) {
+		for key, values := range c.defaultHeaders {
+			req.Header[key] = values
+		}
+	}
+	c.setRequestIDHeader(ctx, req)
+
+	release, err := c.acquireSlot(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("mouser: image request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", &MouserError{
+			StatusCode: resp.StatusCode,
+			Message:    http.StatusText(resp.StatusCode),
+			Endpoint:   imageURL,
+			RequestID:  requestIDFrom(ctx),
+		}
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return "", fmt.Errorf("mouser: failed to read image: %w", err)
+	}
+	return resp.Header.Get("Content-Type"), nil
+}
+
+// resolveImageURL returns the absolute URL for an ImagePath.
+func resolveImageURL(imagePath string) (string, error) {
+	imagePath = strings.TrimSpace(imagePath)
+	if imagePath == "" {
+		return "", fmt.Errorf("%w: part has no image", ErrNotFound)
+	}
+
+	base, _ := url.Parse(imageBaseURL)
+	ref, err := url.Parse(imagePath)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid image path %q", ErrInvalidResponse, imagePath)
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// sameHost reports whether two URLs have the same host and port.
+func sameHost(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	return errA == nil && errB == nil && ua.Host != "" && strings.EqualFold(ua.Host, ub.Host)
+}
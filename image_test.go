@@ -0,0 +1,100 @@
+package mouser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestDownloadImageMock tests streaming a part image.
+func TestDownloadImageMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("apiKey") {
+			t.Error("image requests must not carry the API key")
+		}
+		if r.URL.Path != "/images/ne555.jpg" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("jpeg-bytes"))
+	})
+
+	client := newTestClient(t, handler)
+	base := strings.TrimSuffix(client.baseURL, "/")
+
+	var buf bytes.Buffer
+	contentType, err := client.Search.DownloadImage(context.Background(), Part{ImagePath: base + "/images/ne555.jpg"}, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contentType != "image/jpeg" {
+		t.Errorf("expected image/jpeg, got %s", contentType)
+	}
+	if buf.String() != "jpeg-bytes" {
+		t.Errorf("unexpected image body: %q", buf.String())
+	}
+
+	_, err = client.Search.DownloadImage(context.Background(), Part{ImagePath: base + "/images/missing.jpg"}, &buf)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a 404, got %v", err)
+	}
+
+	_, err = client.Search.DownloadImage(context.Background(), Part{}, &buf)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a part without image, got %v", err)
+	}
+}
+
+// TestDownloadImageDefaultHeadersMock tests that default headers are only
+// sent to an image host that is also the API host.
+func TestDownloadImageDefaultHeadersMock(t *testing.T) {
+	var got []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-Gateway-Token"))
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("jpeg-bytes"))
+	})
+	api := httptest.NewServer(handler)
+	defer api.Close()
+	images := httptest.NewServer(handler)
+	defer images.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(api.URL), WithoutRetry(), WithoutCache(),
+		WithDefaultHeaders(http.Header{"X-Gateway-Token": {"secret"}}))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	var buf bytes.Buffer
+	for _, base := range []string{images.URL, api.URL} {
+		if _, err := client.Search.DownloadImage(context.Background(), Part{ImagePath: base + "/images/ne555.jpg"}, &buf); err != nil {
+			t.Fatalf("DownloadImage: %v", err)
+		}
+	}
+	if len(got) != 2 || got[0] != "" || got[1] != "secret" {
+		t.Errorf("expected the token only on the API host, got %q", got)
+	}
+}
+
+// TestResolveImageURL tests resolving relative image paths against mouser.com.
+func TestResolveImageURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"https://www.mouser.com/images/ti/lrg/ne555.jpg", "https://www.mouser.com/images/ti/lrg/ne555.jpg"},
+		{"/images/ti/lrg/ne555.jpg", "https://www.mouser.com/images/ti/lrg/ne555.jpg"},
+		{"images/ti/lrg/ne555.jpg", "https://www.mouser.com/images/ti/lrg/ne555.jpg"},
+	}
+	for _, tt := range tests {
+		got, err := resolveImageURL(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("resolveImageURL(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
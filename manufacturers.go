@@ -0,0 +1,187 @@
+package mouser
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// FindManufacturers returns the manufacturers whose names start with prefix,
+// compared case-insensitively. The full list comes from ManufacturerList and
+// is therefore served from the manufacturer cache when available.
+// An empty prefix returns every manufacturer.
+func (s *SearchService) FindManufacturers(ctx context.Context, prefix string) ([]Manufacturer, error) {
+	list, err := s.ManufacturerList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+
+	var matches []Manufacturer
+	for _, m := range list.ManufacturerList {
+		if strings.HasPrefix(strings.ToLower(m.ManufacturerName), prefix) {
+			matches = append(matches, m)
+		}
+	}
+
+	return matches, nil
+}
+
+// ManufacturerMap returns the manufacturer list as a lookup map keyed by the
+// lowercased manufacturer name. Use strings.ToLower on the lookup key to get
+// case-insensitive matching.
+func (s *SearchService) ManufacturerMap(ctx context.Context) (map[string]Manufacturer, error) {
+	list, err := s.ManufacturerList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]Manufacturer, len(list.ManufacturerList))
+	for _, mfr := range list.ManufacturerList {
+		m[strings.ToLower(mfr.ManufacturerName)] = mfr
+	}
+
+	return m, nil
+}
+
+// manufacturerNameNoise contains corporate suffixes and filler words that are
+// ignored when comparing manufacturer names.
+var manufacturerNameNoise = map[string]bool{
+	"the": true, "inc": true, "incorporated": true, "corp": true, "corporation": true,
+	"co": true, "company": true, "ltd": true, "limited": true, "llc": true,
+	"gmbh": true, "ag": true, "sa": true, "bv": true, "plc": true, "group": true,
+}
+
+// ResolveManufacturer maps a loosely written manufacturer name (e.g. "TI",
+// "ST Micro", "texas instruments inc") to the canonical name from the
+// manufacturer list, for use with the manufacturer-filtered searches.
+//
+// Matching is tried in order of confidence: exact (case-insensitive), equal
+// after normalization (punctuation and corporate suffixes removed), prefix of
+// the normalized name, acronym of the name's words, and finally a small
+// Levenshtein distance. When several manufacturers match equally well, the
+// shortest name wins. It returns false if nothing matches or the list cannot
+// be fetched.
+func (s *SearchService) ResolveManufacturer(ctx context.Context, query string) (string, bool) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", false
+	}
+
+	list, err := s.ManufacturerList(ctx)
+	if err != nil {
+		return "", false
+	}
+
+	queryWords := manufacturerNameWords(query)
+	queryCompact := strings.Join(queryWords, "")
+	if queryCompact == "" {
+		return "", false
+	}
+
+	best := ""
+	bestScore := -1
+	for _, m := range list.ManufacturerList {
+		name := m.ManufacturerName
+		if strings.EqualFold(name, query) {
+			return name, true
+		}
+
+		score, ok := manufacturerMatchScore(queryCompact, manufacturerNameWords(name))
+		if !ok {
+			continue
+		}
+		if bestScore < 0 || score < bestScore || (score == bestScore && len(name) < len(best)) {
+			best = name
+			bestScore = score
+		}
+	}
+
+	return best, bestScore >= 0
+}
+
+// manufacturerMatchScore scores how well a normalized query matches a
+// manufacturer's normalized words. Lower is better.
+func manufacturerMatchScore(queryCompact string, words []string) (int, bool) {
+	compact := strings.Join(words, "")
+	if compact == "" {
+		return 0, false
+	}
+
+	switch {
+	case compact == queryCompact:
+		return 0, true
+	case len(queryCompact) >= 3 && strings.HasPrefix(compact, queryCompact):
+		return 1, true
+	case len(queryCompact) >= 2 && len(words) >= 2 && manufacturerAcronym(words) == queryCompact:
+		return 2, true
+	}
+
+	maxDistance := len(queryCompact) / 5
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+	if d := levenshtein(queryCompact, compact); d <= maxDistance {
+		return 3 + d, true
+	}
+
+	return 0, false
+}
+
+// manufacturerNameWords lowercases a manufacturer name, splits it on anything
+// that isn't a letter or digit, and drops corporate suffixes.
+func manufacturerNameWords(name string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := fields[:0]
+	for _, f := range fields {
+		if !manufacturerNameNoise[f] {
+			words = append(words, f)
+		}
+	}
+	return words
+}
+
+// sameManufacturer reports whether two manufacturer names are equal once
+// case, punctuation, and corporate suffixes are ignored.
+func sameManufacturer(a, b string) bool {
+	wa, wb := manufacturerNameWords(a), manufacturerNameWords(b)
+	return len(wa) > 0 && strings.Join(wa, " ") == strings.Join(wb, " ")
+}
+
+// manufacturerAcronym returns the first letter of each word.
+func manufacturerAcronym(words []string) string {
+	var b strings.Builder
+	for _, w := range words {
+		r := []rune(w)
+		b.WriteRune(r[0])
+	}
+	return b.String()
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
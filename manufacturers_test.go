@@ -0,0 +1,167 @@
+package mouser
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func manufacturerListFixture() string {
+	return `{
+		"Errors": [],
+		"MouserManufacturerList": {
+			"Count": 5,
+			"ManufacturerList": [
+				{"ManufacturerName": "Texas Instruments"},
+				{"ManufacturerName": "STMicroelectronics"},
+				{"ManufacturerName": "Microchip"},
+				{"ManufacturerName": "Micron"},
+				{"ManufacturerName": "Murata Electronics"}
+			]
+		}
+	}`
+}
+
+func manufacturerListHandler(t *testing.T, calls *int) http.Handler {
+	t.Helper()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls != nil {
+			*calls++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(manufacturerListFixture()))
+	})
+}
+
+// TestFindManufacturersMock tests case-insensitive prefix filtering.
+func TestFindManufacturersMock(t *testing.T) {
+	client := newTestClient(t, manufacturerListHandler(t, nil))
+
+	mfrs, err := client.Search.FindManufacturers(context.Background(), "MIC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mfrs) != 2 {
+		t.Fatalf("expected 2 matches, got %d: %+v", len(mfrs), mfrs)
+	}
+	if mfrs[0].ManufacturerName != "Microchip" || mfrs[1].ManufacturerName != "Micron" {
+		t.Errorf("unexpected matches: %+v", mfrs)
+	}
+
+	none, err := client.Search.FindManufacturers(context.Background(), "zzz")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(none) != 0 {
+		t.Errorf("expected no matches, got %+v", none)
+	}
+}
+
+// TestFindManufacturersCachedMock tests that repeated lookups reuse the cached list.
+func TestFindManufacturersCachedMock(t *testing.T) {
+	calls := 0
+	client := newTestClientCached(t, manufacturerListHandler(t, &calls))
+
+	for _, prefix := range []string{"t", "s", "m"} {
+		if _, err := client.Search.FindManufacturers(context.Background(), prefix); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 API call, got %d", calls)
+	}
+}
+
+// TestManufacturerMapMock tests the case-insensitive manufacturer lookup map.
+func TestManufacturerMapMock(t *testing.T) {
+	client := newTestClient(t, manufacturerListHandler(t, nil))
+
+	m, err := client.Search.ManufacturerMap(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m) != 5 {
+		t.Errorf("expected 5 entries, got %d", len(m))
+	}
+	mfr, ok := m["stmicroelectronics"]
+	if !ok || mfr.ManufacturerName != "STMicroelectronics" {
+		t.Errorf("expected STMicroelectronics entry, got %+v (ok=%v)", mfr, ok)
+	}
+}
+
+// TestResolveManufacturerMock tests fuzzy manufacturer name resolution.
+func TestResolveManufacturerMock(t *testing.T) {
+	client := newTestClient(t, manufacturerListHandler(t, nil))
+
+	testCases := []struct {
+		query string
+		want  string
+		ok    bool
+	}{
+		{"Texas Instruments", "Texas Instruments", true},
+		{"texas instruments inc.", "Texas Instruments", true},
+		{"TI", "Texas Instruments", true},
+		{"ST Micro", "STMicroelectronics", true},
+		{"Murata", "Murata Electronics", true},
+		{"Microchp", "Microchip", true},
+		{"Nobody Semiconductor", "", false},
+		{"", "", false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := client.Search.ResolveManufacturer(context.Background(), tc.query)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("ResolveManufacturer(%q) = (%q, %v), want (%q, %v)", tc.query, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+// TestKeywordAndManufacturerSearchResolvesManufacturerMock tests the ResolveManufacturer search option.
+func TestKeywordAndManufacturerSearchResolvesManufacturerMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/search/manufacturerlist" {
+			_, _ = w.Write([]byte(manufacturerListFixture()))
+			return
+		}
+
+		var req keywordAndManufacturerSearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to parse request: %v", err)
+		}
+		if got := req.SearchByKeywordMfrNameRequest.ManufacturerName; got != "STMicroelectronics" {
+			t.Errorf("expected resolved manufacturer STMicroelectronics, got %q", got)
+		}
+		_, _ = w.Write([]byte(`{"Errors":[],"SearchResults":{"NumberOfResult":0,"Parts":[]}}`))
+	})
+
+	client := newTestClient(t, handler)
+	_, err := client.Search.KeywordAndManufacturerSearch(context.Background(), KeywordAndManufacturerSearchOptions{
+		Keyword:             "microcontroller",
+		ManufacturerName:    "ST Micro",
+		ResolveManufacturer: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestLevenshtein tests the edit distance helper.
+func TestLevenshtein(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"microchip", "microchp", 1},
+	}
+
+	for _, tc := range testCases {
+		if got := levenshtein(tc.a, tc.b); got != tc.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
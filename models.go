@@ -1,5 +1,7 @@
 package mouser
 
+import "time"
+
 // SearchOptions contains options for keyword search requests.
 type SearchOptions struct {
 	// Keyword is the search term.
@@ -16,6 +18,10 @@ type SearchOptions struct {
 
 	// SearchOption filters results. Valid values: None, Rohs, InStock, RohsAndInStock
 	SearchOption SearchOptionType
+
+	// ErrorOnEmpty makes the search return ErrNoResults instead of an empty
+	// result when nothing matches.
+	ErrorOnEmpty bool
 }
 
 // SearchOptionType defines search filter options.
@@ -31,7 +37,8 @@ const (
 // PartNumberSearchOptions contains options for part number search requests.
 type PartNumberSearchOptions struct {
 	// PartNumber is the part number to search for.
-	// Multiple part numbers can be separated by pipe (|), max 10.
+	// Multiple part numbers can be separated by pipe (|), up to MaxPartNumbers.
+	// Requests with more part numbers fail with a *TooManyPartsError.
 	PartNumber string
 
 	// Deprecated: Records is not supported by the V1 part number search API and is ignored.
@@ -45,6 +52,10 @@ type PartNumberSearchOptions struct {
 
 	// PartSearchOption controls matching. Valid values: None, Exact
 	PartSearchOption PartSearchOptionType
+
+	// ErrorOnEmpty makes the search return ErrNoResults instead of an empty
+	// result when nothing matches.
+	ErrorOnEmpty bool
 }
 
 // PartSearchOptionType defines part search matching options.
@@ -64,10 +75,16 @@ type KeywordAndManufacturerSearchOptions struct {
 	// Use GetManufacturerList to get valid manufacturer names.
 	ManufacturerName string
 
+	// ResolveManufacturer maps ManufacturerName to its canonical form with
+	// SearchService.ResolveManufacturer before searching. If no match is found
+	// the name is sent unchanged.
+	ResolveManufacturer bool
+
 	// Records is the maximum number of results to return (max 50).
 	Records int
 
-	// PageNumber is the page number for pagination (1-based).
+	// PageNumber is the page number for pagination (1-based). 0 means the
+	// first page; negative values are rejected.
 	PageNumber int
 
 	// SearchOption filters results. Valid values: None, Rohs, InStock, RohsAndInStock
@@ -75,19 +92,33 @@ type KeywordAndManufacturerSearchOptions struct {
 
 	// SearchWithYourSignUpLanguage uses the language from your Mouser account.
 	SearchWithYourSignUpLanguage bool
+
+	// ErrorOnEmpty makes the search return ErrNoResults instead of an empty
+	// result when nothing matches.
+	ErrorOnEmpty bool
 }
 
 // PartNumberAndManufacturerSearchOptions contains options for part number and manufacturer search.
 type PartNumberAndManufacturerSearchOptions struct {
 	// PartNumber is the Mouser part number to search for.
-	// Multiple part numbers can be separated by pipe (|), max 10.
+	// Multiple part numbers can be separated by pipe (|), up to MaxPartNumbers.
+	// Requests with more part numbers fail with a *TooManyPartsError.
 	PartNumber string
 
 	// ManufacturerName is the manufacturer name to filter by.
 	ManufacturerName string
 
+	// ResolveManufacturer maps ManufacturerName to its canonical form with
+	// SearchService.ResolveManufacturer before searching. If no match is found
+	// the name is sent unchanged.
+	ResolveManufacturer bool
+
 	// PartSearchOption controls matching. Valid values: None, Exact
 	PartSearchOption PartSearchOptionType
+
+	// ErrorOnEmpty makes the search return ErrNoResults instead of an empty
+	// result when nothing matches.
+	ErrorOnEmpty bool
 }
 
 // SearchResult represents the result of a search operation.
@@ -97,6 +128,12 @@ type SearchResult struct {
 
 	// Parts is the list of matching parts.
 	Parts []Part `json:"Parts"`
+
+	// StartingRecord is the 0-based index of the first part in Parts within
+	// all NumberOfResult matches. It is set by the keyword searches, from
+	// SearchOptions.StartingRecord or computed from the page number and
+	// size, and is not part of the API response.
+	StartingRecord int `json:"-"`
 }
 
 // Part represents a component from Mouser's catalog.
@@ -231,6 +268,12 @@ type AvailabilityOnOrderObject struct {
 	Date string `json:"Date"`
 }
 
+// Time parses Date. It returns an error wrapping ErrInvalidResponse if the
+// date is missing or in an unknown format.
+func (a AvailabilityOnOrderObject) Time() (time.Time, error) {
+	return parseMouserDateField("Date", a.Date)
+}
+
 // PriceBreak represents a quantity-based price break.
 type PriceBreak struct {
 	// Quantity is the minimum quantity for this price break.
@@ -278,8 +321,8 @@ type UnitWeight struct {
 
 // StandardCost represents standard cost information.
 type StandardCost struct {
-	// StandardCost is the cost value.
-	StandardCost float64 `json:"StandardCost"`
+	// StandardCost is the cost value. Mouser spells the key "Standardcost".
+	StandardCost float64 `json:"Standardcost"`
 }
 
 // ProductCompliance represents product compliance information.
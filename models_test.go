@@ -0,0 +1,127 @@
+package mouser
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// mouserPartJSON is a part in the shape Mouser returns it, with every field
+// Part models.
+const mouserPartJSON = `{
+	"MouserPartNumber": "595-NE555DR",
+	"ManufacturerPartNumber": "NE555DR",
+	"Manufacturer": "Texas Instruments",
+	"Description": "Timers & Support Products Single Precision Timer",
+	"DataSheetUrl": "https://www.mouser.com/datasheet/2/405/ne555-1.pdf",
+	"ImagePath": "https://www.mouser.com/images/ti/images/SOIC_8_t.jpg",
+	"Category": "Timers & Support Products",
+	"Availability": "12345 In Stock",
+	"AvailabilityInStock": "12345",
+	"AvailabilityOnOrder": [{"Quantity": 2500, "Date": "2024-03-01T00:00:00"}],
+	"FactoryStock": "0",
+	"LifecycleStatus": "New Product",
+	"ROHSStatus": "RoHS Compliant",
+	"LeadTime": "42 Days",
+	"Min": "1",
+	"Mult": "1",
+	"ProductDetailUrl": "https://www.mouser.com/ProductDetail/Texas-Instruments/NE555DR",
+	"PriceBreaks": [
+		{"Quantity": 1, "Price": "$0.42", "Currency": "USD"},
+		{"Quantity": 2500, "Price": "$0.131", "Currency": "USD"}
+	],
+	"AlternatePackagings": [{"APMfrPN": "NE555DRG4"}],
+	"ProductAttributes": [{"AttributeName": "Packaging", "AttributeValue": "Reel", "AttributeCost": ""}],
+	"UnitWeightKg": {"UnitWeight": 0.000076},
+	"StandardCost": {"Standardcost": 0.0817},
+	"Reeling": true,
+	"SuggestedReplacement": "",
+	"MultiSimBlue": 0,
+	"InfoMessages": [],
+	"IsDiscontinued": "false",
+	"MouserProductCategory": "Timers & Support Products",
+	"IPCCode": "CAP",
+	"ProductCompliance": [{"ComplianceName": "USHTS", "ComplianceValue": "8542390090"}],
+	"RestrictionMessage": "",
+	"ActualMfrName": "Texas Instruments",
+	"AvailableOnOrder": "2500",
+	"PID": "12345",
+	"REACH-SVHC": ["Lead"],
+	"RTM": "",
+	"SField": "",
+	"SalesMaximumOrderQty": "",
+	"SurchargeMessages": [{"code": "TARIFF", "message": "Tariff may apply"}],
+	"VNum": ""
+}`
+
+// assertJSONRoundTrip decodes raw into v, encodes it again, and checks the
+// result has the same keys and values as raw.
+func assertJSONRoundTrip(t *testing.T, raw string, v interface{}) {
+	t.Helper()
+
+	if err := json.Unmarshal([]byte(raw), v); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var want, got interface{}
+	if err := json.Unmarshal([]byte(raw), &want); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip changed the JSON\n got: %s\nwant: %s", out, raw)
+	}
+}
+
+// TestPartJSONRoundTrip tests that a Part marshals back to Mouser's shape.
+func TestPartJSONRoundTrip(t *testing.T) {
+	var part Part
+	assertJSONRoundTrip(t, mouserPartJSON, &part)
+
+	if part.StandardCost.StandardCost != 0.0817 {
+		t.Errorf("expected StandardCost 0.0817, got %v", part.StandardCost.StandardCost)
+	}
+	if len(part.REACH_SVHC) != 1 || len(part.SurchargeMessages) != 1 || part.SurchargeMessages[0].Code != "TARIFF" {
+		t.Errorf("unexpected REACH-SVHC or surcharge fields: %+v, %+v", part.REACH_SVHC, part.SurchargeMessages)
+	}
+
+	data, err := json.Marshal(part)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var again Part
+	if err := json.Unmarshal(data, &again); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(again, part) {
+		t.Errorf("Part changed across marshal/unmarshal\n got: %+v\nwant: %+v", again, part)
+	}
+}
+
+// TestSearchResultJSONRoundTrip tests that a SearchResult marshals back to
+// Mouser's shape.
+func TestSearchResultJSONRoundTrip(t *testing.T) {
+	raw := `{"NumberOfResult": 1, "Parts": [` + mouserPartJSON + `]}`
+
+	var result SearchResult
+	assertJSONRoundTrip(t, raw, &result)
+
+	if result.NumberOfResult != 1 || len(result.Parts) != 1 {
+		t.Errorf("unexpected result %d/%d", result.NumberOfResult, len(result.Parts))
+	}
+}
+
+// TestStandardCostDecodesEitherCase tests that cached Parts written with
+// the old "StandardCost" key still load.
+func TestStandardCostDecodesEitherCase(t *testing.T) {
+	var cost StandardCost
+	if err := json.Unmarshal([]byte(`{"StandardCost": 1.5}`), &cost); err != nil || cost.StandardCost != 1.5 {
+		t.Errorf("expected 1.5, got %v, %v", cost.StandardCost, err)
+	}
+}
@@ -51,6 +51,10 @@
 //   - Search.ManufacturerList: V2 endpoint
 //   - Cart, OrderHistory, and Order endpoints: V1 endpoints
 //
+// All calls use the version in the base URL (V2 by default). Use
+// WithAPIVersion or WithEndpointGroupAPIVersion to send an endpoint group to
+// another version; V2-only search endpoints always use V2.
+//
 // # Example Usage
 //
 //	client, err := mouser.NewClient("your-api-key")
@@ -3,7 +3,10 @@ package mouser
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/url"
+	"time"
 )
 
 // QueryOptions queries available order options (shipping, payment, etc.) for a cart.
@@ -24,13 +27,74 @@ func (s *OrderService) QueryOptions(ctx context.Context, req OrderOptionsRequest
 	return &resp, nil
 }
 
+// QueryOptionsForItems previews order options, such as shipping rates, for
+// items that are not in a cart yet. The Mouser API only quotes options for a
+// cart, so this inserts the items into a new cart, queries its options for
+// addr and currencyCode, and, unless keepCart is true, removes the items
+// again. Mouser has no endpoint to delete a cart; an emptied cart simply
+// expires.
+//
+// The cart key is returned in every case in which the cart was created, so
+// it can be reused or cleaned up. If the options were retrieved but emptying
+// the cart failed, the options are returned together with the error.
+// If some items fail to insert, either as a *CartLineErrorsError with
+// WithStrictCartLines or as a *CartInsertChunkError with
+// WithCartInsertChunkSize, no options are queried: the error is returned
+// with the cart key, and the cart is still emptied unless keepCart is true.
+func (s *OrderService) QueryOptionsForItems(ctx context.Context, items []CartItemRequest, addr OrderAddress, currencyCode string, keepCart bool) (*OrderOptionsResponse, string, error) {
+	c := s.client
+
+	if len(items) == 0 {
+		return nil, "", fmt.Errorf("%w: no items to quote", ErrInvalidRequest)
+	}
+
+	cart, err := c.Cart.InsertItems(ctx, CartItemRequestBody{CartItems: items}, addr.CountryCode, currencyCode)
+	if cart == nil {
+		return nil, "", err
+	}
+	cartKey := cart.CartKey
+
+	// A partially filled cart is not quoted but is still emptied.
+	var options *OrderOptionsResponse
+	if err == nil {
+		options, err = s.QueryOptions(ctx, OrderOptionsRequest{
+			ShippingAddress: &addr,
+			CurrencyCode:    currencyCode,
+			CartKey:         cartKey,
+		})
+	}
+
+	if !keepCart {
+		// Cleanup waits for the rate limiter rather than leaving items behind.
+		cleanupCtx := withRateLimitWait(ctx)
+		var errs []error
+		for _, line := range cart.CartItems {
+			if _, rmErr := c.Cart.RemoveItem(cleanupCtx, cartKey, line.MouserPartNumber, addr.CountryCode, currencyCode); rmErr != nil {
+				errs = append(errs, fmt.Errorf("removing %s: %w", line.MouserPartNumber, rmErr))
+			}
+		}
+		if len(errs) > 0 {
+			cleanupErr := fmt.Errorf("mouser: failed to empty quote cart %s: %w", cartKey, errors.Join(errs...))
+			if err != nil {
+				return nil, cartKey, errors.Join(err, cleanupErr)
+			}
+			return options, cartKey, cleanupErr
+		}
+	}
+
+	if err != nil {
+		return nil, cartKey, err
+	}
+	return options, cartKey, nil
+}
+
 // Currencies retrieves the list of available currencies.
 // Results are cached for 24 hours by default.
 func (s *OrderService) Currencies(ctx context.Context, shippingCountryCode string) (*CurrenciesResponse, error) {
 	c := s.client
 
 	cacheKey := cacheKeyForCurrencies(shippingCountryCode)
-	if cached, ok := c.getCached(cacheKey); ok {
+	if cached, ok := c.getCached(ctx, cacheKey); ok {
 		var result CurrenciesResponse
 		if err := json.Unmarshal(cached, &result); err == nil {
 			return &result, nil
@@ -64,7 +128,7 @@ func (s *OrderService) Countries(ctx context.Context, countryCode string) (*Coun
 	c := s.client
 
 	cacheKey := cacheKeyForCountries(countryCode)
-	if cached, ok := c.getCached(cacheKey); ok {
+	if cached, ok := c.getCached(ctx, cacheKey); ok {
 		var result CountriesResponse
 		if err := json.Unmarshal(cached, &result); err == nil {
 			return &result, nil
@@ -92,28 +156,55 @@ func (s *OrderService) Countries(ctx context.Context, countryCode string) (*Coun
 	return &resp, nil
 }
 
-// Create creates a new order from a cart.
+// Create creates a new order from a cart. The request is checked with
+// CreateOrderRequest.Validate before it is sent.
+// When req.SubmitOrder is true the request is never retried, whatever the
+// client's RetryConfig, since a retry after a lost response could place the
+// order twice.
+// If Mouser assigns an order number but also reports errors, both the
+// response and an *OrderWarningsError (wrapping ErrOrderWarnings) are returned.
+// An order rejected for being below the regional minimum value fails with a
+// *BelowMinimumOrderError (wrapping ErrBelowMinimumOrder).
+// If a submission was sent but no definitive answer came back (the context
+// was cancelled or timed out, the connection failed, or Mouser returned a 5xx
+// or unreadable response), the error is a *SubmitStatusUnknownError wrapping
+// both ErrSubmitStatusUnknown and the cause. The order may have been placed;
+// use ReconcileSubmit before trying again. Failures before the request is
+// sent, such as validation or rate limit errors, are returned unchanged.
 func (s *OrderService) Create(ctx context.Context, req CreateOrderRequest) (*OrderResponse, error) {
 	c := s.client
 
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
 	wrapped := createOrderRequestWrapper{CreateOrderRequest: req}
 
-	var resp OrderResponse
-	if err := c.doRequest(ctx, "POST", "/order", wrapped, &resp); err != nil {
-		return nil, err
+	var call *callStats
+	submittedAt := time.Now()
+	if req.SubmitOrder {
+		ctx, call = withCallStats(withoutRetries(ctx))
 	}
 
-	if len(resp.Errors) > 0 {
-		return nil, APIErrors(resp.Errors)
+	var resp OrderResponse
+	if err := c.doRequest(ctx, "POST", "/order", wrapped, &resp); err != nil {
+		return nil, submitError(orderError(err), call, req.CartKey, submittedAt)
 	}
 
-	return &resp, nil
+	return orderResult(&resp)
 }
 
-// CreateFromPrevious creates a new order based on a previous order.
+// CreateFromPrevious creates a new order based on a previous order. The
+// request is validated as in Create, except that no CartKey is required.
+// Submitting requests are not retried, and partial success and unknown
+// submission status are reported the same way as in Create.
 func (s *OrderService) CreateFromPrevious(ctx context.Context, orderNumber, countryCode, currencyCode string, req CreateOrderRequest) (*OrderResponse, error) {
 	c := s.client
 
+	if err := req.validate(false); err != nil {
+		return nil, err
+	}
+
 	query := url.Values{}
 	query.Set("orderNumber", orderNumber)
 	if countryCode != "" {
@@ -125,16 +216,110 @@ func (s *OrderService) CreateFromPrevious(ctx context.Context, orderNumber, coun
 
 	wrapped := createOrderRequestWrapper{CreateOrderRequest: req}
 
+	var call *callStats
+	submittedAt := time.Now()
+	if req.SubmitOrder {
+		ctx, call = withCallStats(withoutRetries(ctx))
+	}
+
 	var resp OrderResponse
 	if err := c.doRequestWithQuery(ctx, "POST", "/order/CreateFromOrder", query, wrapped, &resp); err != nil {
-		return nil, err
+		return nil, submitError(orderError(err), call, req.CartKey, submittedAt)
 	}
 
-	if len(resp.Errors) > 0 {
-		return nil, APIErrors(resp.Errors)
+	return orderResult(&resp)
+}
+
+// orderResult converts an order creation response into the return values of
+// Create and CreateFromPrevious. Errors with an order number mean the order
+// may have gone through, so the response is returned with an
+// *OrderWarningsError. A CartKey alone is not enough, since Mouser echoes it
+// on failed orders too.
+func orderResult(resp *OrderResponse) (*OrderResponse, error) {
+	if len(resp.Errors) == 0 {
+		return resp, nil
+	}
+	if resp.OrderNumber != "" {
+		return resp, &OrderWarningsError{OrderNumber: resp.OrderNumber, Warnings: APIErrors(resp.Errors)}
+	}
+	if minErr := belowMinimumOrder(resp.Errors, APIErrors(resp.Errors)); minErr != nil {
+		return nil, minErr
 	}
+	return nil, APIErrors(resp.Errors)
+}
 
-	return &resp, nil
+// submitError wraps err in a *SubmitStatusUnknownError if a submission was
+// sent but did not get a definitive answer. call is nil for requests that
+// do not submit. A 4xx response means Mouser rejected the order, so only
+// other outcomes leave the status unknown.
+func submitError(err error, call *callStats, cartKey string, submittedAt time.Time) error {
+	if call == nil || call.sent == 0 {
+		return err
+	}
+	var mErr *MouserError
+	if errors.As(err, &mErr) && mErr.StatusCode >= 400 && mErr.StatusCode < 500 {
+		return err
+	}
+	var minErr *BelowMinimumOrderError
+	if errors.As(err, &minErr) {
+		return err
+	}
+	return &SubmitStatusUnknownError{CartKey: cartKey, SubmittedAt: submittedAt, Err: err}
+}
+
+// orderError classifies a failed order request. Mouser may reject an order
+// below the minimum value with an HTTP error status, in which case the API
+// errors are only available in the raw response body.
+func orderError(err error) error {
+	var mErr *MouserError
+	if !errors.As(err, &mErr) {
+		return err
+	}
+	errs := mErr.Errors
+	var body struct {
+		Errors []APIError `json:"Errors"`
+	}
+	if json.Unmarshal(mErr.RawBody, &body) == nil {
+		errs = append(errs, body.Errors...)
+	}
+	if minErr := belowMinimumOrder(errs, err); minErr != nil {
+		return minErr
+	}
+	return err
+}
+
+// ReconcileSubmit looks for orders that may have been placed by a submission
+// that failed with a *SubmitStatusUnknownError. It returns the order history
+// entries created since the day before the submission (to allow for Mouser's
+// time zone), including entries whose creation date cannot be parsed.
+// Order history does not record cart keys, so the candidates must be
+// confirmed by the caller (for example by PoNumber or with
+// OrderHistory.FullOrder) before the order is resubmitted. An empty result
+// suggests, but does not prove, that the order was not placed: new orders
+// can take a while to appear in the history.
+func (s *OrderService) ReconcileSubmit(ctx context.Context, unknown *SubmitStatusUnknownError) ([]OrderHistoryItem, error) {
+	if unknown == nil || unknown.SubmittedAt.IsZero() {
+		return nil, fmt.Errorf("%w: submission time is required", ErrInvalidRequest)
+	}
+
+	since := unknown.SubmittedAt.UTC().AddDate(0, 0, -1)
+	since = time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.UTC)
+	until := time.Now().UTC().AddDate(0, 0, 1)
+
+	history, err := s.client.OrderHistory.ByDateRange(ctx, since.Format(orderHistoryDateLayout), until.Format(orderHistoryDateLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []OrderHistoryItem
+	for _, order := range history.OrderHistoryItems {
+		created, err := order.Created()
+		if err == nil && created.Before(since) {
+			continue
+		}
+		candidates = append(candidates, order)
+	}
+	return candidates, nil
 }
 
 // Details retrieves details for a specific order by order number.
@@ -1,5 +1,11 @@
 package mouser
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // AddressLocationTypeID defines the type of address location.
 type AddressLocationTypeID string
 
@@ -114,6 +120,42 @@ type ShippingOptions struct {
 	FreightAccounts []FreightAccount `json:"FreightAccounts"`
 }
 
+// SortedByRate returns a copy of the shipping methods ordered cheapest
+// first. Methods with equal rates keep their API order.
+func (o ShippingOptions) SortedByRate() []ShippingMethod {
+	methods := append([]ShippingMethod(nil), o.Methods...)
+	sort.SliceStable(methods, func(i, j int) bool { return methods[i].Rate < methods[j].Rate })
+	return methods
+}
+
+// CheapestMethod returns the shipping method with the lowest rate, the
+// first one on a tie. It returns false if no methods are offered.
+func (o ShippingOptions) CheapestMethod() (ShippingMethod, bool) {
+	if len(o.Methods) == 0 {
+		return ShippingMethod{}, false
+	}
+	cheapest := o.Methods[0]
+	for _, m := range o.Methods[1:] {
+		if m.Rate < cheapest.Rate {
+			cheapest = m
+		}
+	}
+	return cheapest, true
+}
+
+// EstimateTotal returns merchandiseTotal plus the rate of the shipping
+// method with the given Code, for showing an estimated total before the
+// order is created. Taxes and duties are not included. It returns false if
+// the method is not offered.
+func (r *OrderOptionsResponse) EstimateTotal(merchandiseTotal float64, shippingCode int) (float64, bool) {
+	for _, m := range r.Shipping.Methods {
+		if m.Code == shippingCode {
+			return merchandiseTotal + m.Rate, true
+		}
+	}
+	return 0, false
+}
+
 // ShippingMethod represents a shipping method with its rate.
 type ShippingMethod struct {
 	// Method is the shipping method name.
@@ -222,9 +264,50 @@ type CreateOrderRequest struct {
 	LanguageCode string `json:"LanguageCode,omitempty"`
 
 	// SubmitOrder indicates whether to submit the order (true) or just validate (false).
+	// Submitting requests are never retried, to avoid placing an order twice.
 	SubmitOrder bool `json:"SubmitOrder"`
 }
 
+// Validate checks that the request has the fields Mouser requires, so that
+// an incomplete order fails locally instead of spending a rate limit token:
+// a CartKey, a PrimaryShipping method when SubmitOrder is true, and, if a
+// ShippingAddress is given, its CountryCode, AddressOne, City, and
+// PostalCode. It returns an error wrapping ErrInvalidRequest that lists every
+// missing field.
+func (r CreateOrderRequest) Validate() error {
+	return r.validate(true)
+}
+
+// validate implements Validate. CreateFromPrevious orders from a previous
+// order number rather than a cart, so it does not require a CartKey.
+func (r CreateOrderRequest) validate(requireCartKey bool) error {
+	var missing []string
+	if requireCartKey && strings.TrimSpace(r.CartKey) == "" {
+		missing = append(missing, "CartKey")
+	}
+	if r.SubmitOrder && r.PrimaryShipping == 0 {
+		missing = append(missing, "PrimaryShipping")
+	}
+	if addr := r.ShippingAddress; addr != nil {
+		fields := []struct{ name, value string }{
+			{"CountryCode", addr.CountryCode},
+			{"AddressOne", addr.AddressOne},
+			{"City", addr.City},
+			{"PostalCode", addr.PostalCode},
+		}
+		for _, f := range fields {
+			if strings.TrimSpace(f.value) == "" {
+				missing = append(missing, "ShippingAddress."+f.name)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: order request is missing %s", ErrInvalidRequest, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // createOrderRequestWrapper wraps the order request for the API.
 type createOrderRequestWrapper struct {
 	CreateOrderRequest CreateOrderRequest `json:"CreateOrderRequest"`
@@ -3,9 +3,14 @@ package mouser
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func orderOptionsResponse() string {
@@ -120,6 +125,204 @@ func TestQueryOrderOptionsMock(t *testing.T) {
 	}
 }
 
+// TestOrderOptionsEstimateTotal tests estimating a total for a shipping
+// method by code.
+func TestOrderOptionsEstimateTotal(t *testing.T) {
+	opts := OrderOptionsResponse{Shipping: ShippingOptions{Methods: []ShippingMethod{
+		{Method: "FedEx Ground", Rate: 12.50, Code: 1},
+		{Method: "UPS Next Day Air", Rate: 45, Code: 7},
+	}}}
+
+	if total, ok := opts.EstimateTotal(100, 7); !ok || total != 145 {
+		t.Errorf("EstimateTotal(100, 7) = %v, %v; want 145, true", total, ok)
+	}
+	if _, ok := opts.EstimateTotal(100, 3); ok {
+		t.Error("expected false for a shipping code that is not offered")
+	}
+}
+
+// TestShippingOptionsByRate tests sorting shipping methods and picking the
+// cheapest.
+func TestShippingOptionsByRate(t *testing.T) {
+	opts := ShippingOptions{Methods: []ShippingMethod{
+		{Method: "UPS Next Day Air", Rate: 45, Code: 7},
+		{Method: "FedEx Ground", Rate: 12.50, Code: 1},
+		{Method: "UPS Ground", Rate: 12.50, Code: 2},
+	}}
+
+	sorted := opts.SortedByRate()
+	if sorted[0].Code != 1 || sorted[1].Code != 2 || sorted[2].Code != 7 {
+		t.Errorf("unexpected order %+v", sorted)
+	}
+	if opts.Methods[0].Code != 7 {
+		t.Error("SortedByRate should not modify Methods")
+	}
+	if m, ok := opts.CheapestMethod(); !ok || m.Code != 1 {
+		t.Errorf("CheapestMethod() = %+v, %v; want code 1", m, ok)
+	}
+
+	var empty ShippingOptions
+	if _, ok := empty.CheapestMethod(); ok {
+		t.Error("expected false with no methods")
+	}
+	if len(empty.SortedByRate()) != 0 {
+		t.Error("expected no methods")
+	}
+}
+
+// TestQueryOptionsForItemsMock tests quoting items through an ephemeral cart.
+func TestQueryOptionsForItemsMock(t *testing.T) {
+	for _, keepCart := range []bool{false, true} {
+		var removed []string
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/cart/items/insert":
+				_, _ = w.Write([]byte(cartSuccessResponse()))
+			case "/order/options/query":
+				body, _ := io.ReadAll(r.Body)
+				var req orderOptionsRequestWrapper
+				if err := json.Unmarshal(body, &req); err != nil {
+					t.Fatalf("failed to parse request: %v", err)
+				}
+				if req.OrderOptionsRequest.CartKey != "abc-123" {
+					t.Errorf("expected CartKey=abc-123, got %s", req.OrderOptionsRequest.CartKey)
+				}
+				_, _ = w.Write([]byte(orderOptionsResponse()))
+			case "/cart/item/remove":
+				removed = append(removed, r.URL.Query().Get("mouserPartNumber"))
+				_, _ = w.Write([]byte(`{"Errors": [], "CartKey": "abc-123", "CartItems": []}`))
+			default:
+				t.Errorf("unexpected path %s", r.URL.Path)
+			}
+		})
+
+		client := newTestClient(t, handler)
+		opts, cartKey, err := client.Order.QueryOptionsForItems(context.Background(),
+			[]CartItemRequest{{MouserPartNumber: "TEST-001", Quantity: 10}},
+			OrderAddress{CountryCode: "US", City: "Austin"}, "USD", keepCart)
+		if err != nil {
+			t.Fatalf("keepCart=%v: unexpected error: %v", keepCart, err)
+		}
+		if cartKey != "abc-123" {
+			t.Errorf("keepCart=%v: expected cart key abc-123, got %s", keepCart, cartKey)
+		}
+		if len(opts.Shipping.Methods) == 0 {
+			t.Errorf("keepCart=%v: expected shipping methods", keepCart)
+		}
+		if keepCart && len(removed) != 0 {
+			t.Errorf("keepCart=true: expected no removals, got %v", removed)
+		}
+		if !keepCart && (len(removed) != 1 || removed[0] != "TEST-001") {
+			t.Errorf("keepCart=false: expected TEST-001 removed, got %v", removed)
+		}
+	}
+}
+
+// TestQueryOptionsForItemsStrictLinesMock tests that with
+// WithStrictCartLines a failed line skips the quote but still returns the
+// cart key and empties the cart.
+func TestQueryOptionsForItemsStrictLinesMock(t *testing.T) {
+	var removed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/cart/items/insert":
+			_, _ = w.Write([]byte(`{"Errors": [], "CartKey": "abc-123", "CartItems": [
+				{"MouserPartNumber": "TEST-001", "Quantity": 10, "Errors": []},
+				{"MouserPartNumber": "NOPE-1", "Quantity": 1, "Errors": [{"Code": "InvalidPartNumber", "Message": "Invalid part number"}]}
+			]}`))
+		case "/cart/item/remove":
+			removed = append(removed, r.URL.Query().Get("mouserPartNumber"))
+			_, _ = w.Write([]byte(`{"Errors": [], "CartKey": "abc-123", "CartItems": []}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(server.URL), WithoutRetry(), WithoutCache(), WithStrictCartLines())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	opts, cartKey, err := client.Order.QueryOptionsForItems(context.Background(),
+		[]CartItemRequest{{MouserPartNumber: "TEST-001", Quantity: 10}, {MouserPartNumber: "NOPE-1", Quantity: 1}},
+		OrderAddress{CountryCode: "US", City: "Austin"}, "USD", false)
+	if !errors.Is(err, ErrCartLineErrors) {
+		t.Errorf("expected ErrCartLineErrors, got %v", err)
+	}
+	if opts != nil {
+		t.Errorf("expected no options, got %+v", opts)
+	}
+	if cartKey != "abc-123" {
+		t.Errorf("expected cart key abc-123, got %q", cartKey)
+	}
+	if len(removed) != 2 {
+		t.Errorf("expected both lines removed, got %v", removed)
+	}
+}
+
+// TestQueryOptionsForItemsChunkFailsMock tests that the quote cart is
+// emptied and its key returned when a later insert chunk fails.
+func TestQueryOptionsForItemsChunkFailsMock(t *testing.T) {
+	inserts := 0
+	var removed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/cart/items/insert":
+			inserts++
+			if inserts == 2 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte(`{"Errors": [], "CartKey": "abc-123", "CartItems": [{"MouserPartNumber": "TEST-001", "Quantity": 10}]}`))
+		case "/cart/item/remove":
+			removed = append(removed, r.URL.Query().Get("mouserPartNumber"))
+			_, _ = w.Write([]byte(`{"Errors": [], "CartKey": "abc-123", "CartItems": []}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(server.URL), WithoutRetry(), WithoutCache(), WithCartInsertChunkSize(1))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	opts, cartKey, err := client.Order.QueryOptionsForItems(context.Background(),
+		[]CartItemRequest{{MouserPartNumber: "TEST-001", Quantity: 10}, {MouserPartNumber: "TEST-002", Quantity: 1}},
+		OrderAddress{CountryCode: "US", City: "Austin"}, "USD", false)
+	var chunkErr *CartInsertChunkError
+	if !errors.As(err, &chunkErr) {
+		t.Errorf("expected *CartInsertChunkError, got %v", err)
+	}
+	if opts != nil {
+		t.Errorf("expected no options, got %+v", opts)
+	}
+	if cartKey != "abc-123" {
+		t.Errorf("expected cart key abc-123, got %q", cartKey)
+	}
+	if len(removed) != 1 || removed[0] != "TEST-001" {
+		t.Errorf("expected the inserted line removed, got %v", removed)
+	}
+}
+
+// TestQueryOptionsForItemsEmpty tests that quoting no items is rejected locally.
+func TestQueryOptionsForItemsEmpty(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	_, _, err := client.Order.QueryOptionsForItems(context.Background(), nil, OrderAddress{CountryCode: "US"}, "USD", false)
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+}
+
 // TestGetCurrenciesMock tests GetCurrencies with a mock server.
 func TestGetCurrenciesMock(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -355,6 +558,205 @@ func TestOrderErrorHandlingMock(t *testing.T) {
 	}
 }
 
+// TestCreateOrderWithWarningsMock tests that an order number returned alongside
+// errors is surfaced with a warning error instead of being dropped.
+func TestCreateOrderWithWarningsMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"Errors": [{"Id": 1, "Code": "ShippingDelay", "Message": "One line ships later"}],
+			"OrderNumber": "ORD-002",
+			"CartKey": "abc-123",
+			"CurrencyCode": "USD"
+		}`))
+	})
+
+	client := newTestClient(t, handler)
+	resp, err := client.Order.Create(context.Background(), CreateOrderRequest{
+		CartKey:         "abc-123",
+		PrimaryShipping: 1,
+		SubmitOrder:     true,
+	})
+	if !errors.Is(err, ErrOrderWarnings) {
+		t.Fatalf("expected ErrOrderWarnings, got %v", err)
+	}
+	if resp == nil || resp.OrderNumber != "ORD-002" {
+		t.Fatalf("expected response with OrderNumber=ORD-002, got %+v", resp)
+	}
+
+	var warnErr *OrderWarningsError
+	if !errors.As(err, &warnErr) {
+		t.Fatalf("expected *OrderWarningsError, got %T", err)
+	}
+	if warnErr.OrderNumber != "ORD-002" {
+		t.Errorf("expected OrderNumber=ORD-002 on error, got %s", warnErr.OrderNumber)
+	}
+	if _, ok := warnErr.Warnings.ByCode("ShippingDelay"); !ok {
+		t.Errorf("expected ShippingDelay warning, got %v", warnErr.Warnings)
+	}
+}
+
+// TestCreateOrderSubmitNotRetriedMock tests that submitting an order is
+// attempted once even when the client retries, while validation is retried.
+func TestCreateOrderSubmitNotRetriedMock(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Errors": [], "OrderNumber": "ORD-003"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutCache(),
+		WithRetryConfig(RetryConfig{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+
+	_, err = client.Order.Create(ctx, CreateOrderRequest{CartKey: "abc-123", PrimaryShipping: 1, SubmitOrder: true})
+	if !errors.Is(err, ErrServerError) {
+		t.Fatalf("expected ErrServerError from the single attempt, got %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected 1 request for a submitted order, got %d", n)
+	}
+
+	requests.Store(0)
+	_, err = client.Order.CreateFromPrevious(ctx, "ORD-001", "", "", CreateOrderRequest{PrimaryShipping: 1, SubmitOrder: true})
+	if err == nil || requests.Load() != 1 {
+		t.Fatalf("expected a single failed attempt for a submitted reorder, got %d requests, err %v", requests.Load(), err)
+	}
+
+	requests.Store(0)
+	resp, err := client.Order.Create(ctx, CreateOrderRequest{CartKey: "abc-123", SubmitOrder: false})
+	if err != nil {
+		t.Fatalf("expected validation request to succeed after retry, got %v", err)
+	}
+	if resp.OrderNumber != "ORD-003" || requests.Load() != 2 {
+		t.Errorf("expected retried validation, got %d requests and %+v", requests.Load(), resp)
+	}
+}
+
+// TestCreateOrderRequestValidate tests the required field checks.
+func TestCreateOrderRequestValidate(t *testing.T) {
+	address := &OrderAddress{CountryCode: "US", AddressOne: "1000 N Main St", City: "Mansfield", PostalCode: "76063"}
+
+	testCases := []struct {
+		name    string
+		req     CreateOrderRequest
+		missing []string
+	}{
+		{"valid validation request", CreateOrderRequest{CartKey: "abc-123"}, nil},
+		{"valid submission", CreateOrderRequest{CartKey: "abc-123", PrimaryShipping: 1, SubmitOrder: true, ShippingAddress: address}, nil},
+		{"missing cart key", CreateOrderRequest{CartKey: " "}, []string{"CartKey"}},
+		{"submit without shipping", CreateOrderRequest{CartKey: "abc-123", SubmitOrder: true}, []string{"PrimaryShipping"}},
+		{
+			"incomplete address",
+			CreateOrderRequest{ShippingAddress: &OrderAddress{CountryCode: "US", City: "Mansfield"}},
+			[]string{"CartKey", "ShippingAddress.AddressOne", "ShippingAddress.PostalCode"},
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.req.Validate()
+		if len(tc.missing) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: expected ErrInvalidRequest, got %v", tc.name, err)
+			continue
+		}
+		for _, field := range tc.missing {
+			if !strings.Contains(err.Error(), field) {
+				t.Errorf("%s: expected %s in %q", tc.name, field, err.Error())
+			}
+		}
+	}
+}
+
+// TestCreateOrderInvalidRequestNotSentMock tests that invalid orders fail
+// before any request is made.
+func TestCreateOrderInvalidRequestNotSentMock(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+
+	ctx := context.Background()
+	if _, err := client.Order.Create(ctx, CreateOrderRequest{SubmitOrder: true}); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("Create: expected ErrInvalidRequest, got %v", err)
+	}
+	if _, err := client.Order.CreateFromPrevious(ctx, "ORD-001", "", "", CreateOrderRequest{SubmitOrder: true}); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("CreateFromPrevious: expected ErrInvalidRequest, got %v", err)
+	}
+	if stats := client.RateLimitStats(); stats.MinuteUsed != 0 {
+		t.Errorf("expected no rate limit tokens spent, got %d", stats.MinuteUsed)
+	}
+}
+
+// TestCreateOrderErrorsWithoutOrderNumberMock tests that errors without an
+// order number are still treated as a failed order, even if a CartKey is echoed.
+func TestCreateOrderErrorsWithoutOrderNumberMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"Errors": [{"Id": 1, "Code": "InvalidCartKey", "Message": "Cart not found"}],
+			"CartKey": "abc-123"
+		}`))
+	})
+
+	client := newTestClient(t, handler)
+	resp, err := client.Order.Create(context.Background(), CreateOrderRequest{CartKey: "abc-123"})
+	if resp != nil {
+		t.Errorf("expected nil response, got %+v", resp)
+	}
+	if errors.Is(err, ErrOrderWarnings) {
+		t.Error("did not expect ErrOrderWarnings without an order number")
+	}
+	var apiErrs APIErrors
+	if !errors.As(err, &apiErrs) {
+		t.Errorf("expected APIErrors, got %T: %v", err, err)
+	}
+}
+
+// TestCreateOrderBelowMinimumMock tests that minimum order value rejections
+// are reported as *BelowMinimumOrderError, whether Mouser answers with 200 or 400.
+func TestCreateOrderBelowMinimumMock(t *testing.T) {
+	const body = `{"Errors": [{"Code": "OrderBelowMinimum", "Message": "The order total must be at least EUR 50,00 for delivery to this region."}], "CartKey": "abc-123"}`
+
+	for _, status := range []int{http.StatusOK, http.StatusBadRequest} {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		})
+
+		client := newTestClient(t, handler)
+		_, err := client.Order.Create(context.Background(), CreateOrderRequest{CartKey: "abc-123", CurrencyCode: "EUR"})
+		if !errors.Is(err, ErrBelowMinimumOrder) {
+			t.Fatalf("status %d: expected ErrBelowMinimumOrder, got %v", status, err)
+		}
+		var minErr *BelowMinimumOrderError
+		if !errors.As(err, &minErr) {
+			t.Fatalf("status %d: expected *BelowMinimumOrderError, got %T", status, err)
+		}
+		if minErr.Minimum != 50 || minErr.CurrencyCode != "EUR" {
+			t.Errorf("status %d: expected minimum 50 EUR, got %v %q", status, minErr.Minimum, minErr.CurrencyCode)
+		}
+	}
+}
+
 // TestOrderMutationsNotCachedMock tests that mutation endpoints are not cached.
 func TestOrderMutationsNotCachedMock(t *testing.T) {
 	callCount := 0
@@ -473,6 +875,98 @@ func TestCountryModelRoundtrip(t *testing.T) {
 	}
 }
 
+// TestCreateOrderCancelledSubmitMock tests that cancelling a submission in
+// flight reports an unknown order status, while failures before sending and
+// outright rejections do not.
+func TestCreateOrderCancelledSubmitMock(t *testing.T) {
+	received := make(chan struct{}, 1)
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		// Drain the body so the server notices the client hanging up.
+		_, _ = io.Copy(io.Discard, r.Body)
+		received <- struct{}{}
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(server.URL), WithoutRetry(), WithoutCache())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	req := CreateOrderRequest{CartKey: "abc-123", PrimaryShipping: 1, SubmitOrder: true}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-received
+		cancel()
+	}()
+	_, err = client.Order.Create(ctx, req)
+	if !errors.Is(err, ErrSubmitStatusUnknown) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected ErrSubmitStatusUnknown wrapping context.Canceled, got %v", err)
+	}
+	var unknown *SubmitStatusUnknownError
+	if !errors.As(err, &unknown) || unknown.CartKey != "abc-123" || unknown.SubmittedAt.IsZero() {
+		t.Errorf("unexpected SubmitStatusUnknownError: %+v", unknown)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = client.Order.Create(ctx, req)
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrSubmitStatusUnknown) {
+		t.Errorf("expected a plain context error before sending, got %v", err)
+	}
+
+	status = http.StatusBadRequest
+	_, err = client.Order.Create(context.Background(), req)
+	if err == nil || errors.Is(err, ErrSubmitStatusUnknown) {
+		t.Errorf("expected a definitive rejection for a 400, got %v", err)
+	}
+
+	status = http.StatusBadGateway
+	_, err = client.Order.CreateFromPrevious(context.Background(), "ORD-001", "", "", CreateOrderRequest{PrimaryShipping: 1, SubmitOrder: true})
+	if !errors.Is(err, ErrSubmitStatusUnknown) || !errors.Is(err, ErrServerError) {
+		t.Errorf("expected unknown status for a 502, got %v", err)
+	}
+}
+
+// TestReconcileSubmitMock tests that reconciliation lists orders created
+// around or after the submission.
+func TestReconcileSubmitMock(t *testing.T) {
+	submittedAt := time.Now().UTC()
+	var query string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Errors": [], "OrderHistoryItems": [
+			{"SalesOrderNumber": "OLD", "DateCreated": "2020-01-01"},
+			{"SalesOrderNumber": "NEW", "DateCreated": "` + submittedAt.Format("2006-01-02") + `"},
+			{"SalesOrderNumber": "UNDATED", "DateCreated": ""}
+		]}`))
+	}))
+
+	ctx := context.Background()
+	orders, err := client.Order.ReconcileSubmit(ctx, &SubmitStatusUnknownError{CartKey: "abc-123", SubmittedAt: submittedAt})
+	if err != nil {
+		t.Fatalf("ReconcileSubmit: %v", err)
+	}
+	if len(orders) != 2 || orders[0].SalesOrderNumber != "NEW" || orders[1].SalesOrderNumber != "UNDATED" {
+		t.Errorf("unexpected candidates: %+v", orders)
+	}
+	if want := "startDate=" + submittedAt.AddDate(0, 0, -1).Format("2006-01-02"); !strings.Contains(query, want) {
+		t.Errorf("expected query to contain %q, got %q", want, query)
+	}
+
+	if _, err := client.Order.ReconcileSubmit(ctx, &SubmitStatusUnknownError{}); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest without a submission time, got %v", err)
+	}
+}
+
 // Integration tests - only for safe read-only endpoints
 
 // TestIntegrationGetCurrencies tests the real currencies endpoint.
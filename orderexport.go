@@ -0,0 +1,158 @@
+package mouser
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Export formats accepted by OrderHistoryService.ExportLines.
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+)
+
+// OrderLineRecord is one order line as written by ExportLines.
+type OrderLineRecord struct {
+	SalesOrderNumber       string  `json:"SalesOrderNumber"`
+	WebOrderNumber         string  `json:"WebOrderNumber"`
+	OrderDate              string  `json:"OrderDate"`
+	MouserPartNumber       string  `json:"MouserPartNumber"`
+	ManufacturerPartNumber string  `json:"ManufacturerPartNumber"`
+	Quantity               int     `json:"Quantity"`
+	UnitPrice              float64 `json:"UnitPrice"`
+	ExtPrice               float64 `json:"ExtPrice"`
+	CurrencyCode           string  `json:"CurrencyCode"`
+}
+
+// orderLineCSVHeader is the header row ExportLines writes for CSV.
+var orderLineCSVHeader = []string{
+	"SalesOrderNumber",
+	"WebOrderNumber",
+	"OrderDate",
+	"MouserPartNumber",
+	"ManufacturerPartNumber",
+	"Quantity",
+	"UnitPrice",
+	"ExtPrice",
+	"CurrencyCode",
+}
+
+// ExportLines writes every line of every order placed between startDate and
+// endDate (inclusive, formatted "2006-01-02") to w, one OrderLineRecord per
+// order line. format is ExportFormatCSV, for CSV with a header row, or
+// ExportFormatJSON, for newline-delimited JSON objects. OrderDate is
+// formatted "2006-01-02" when it can be parsed.
+//
+// Orders are listed with All and their details fetched one at a time,
+// waiting for the rate limiter rather than failing, and each order's lines
+// are written as soon as they arrive. As with SpendSummary, orders whose
+// details cannot be fetched are skipped and their errors joined into the
+// returned error. A write error stops the export.
+func (s *OrderHistoryService) ExportLines(ctx context.Context, startDate, endDate string, format string, w io.Writer) error {
+	var write func(OrderLineRecord) error
+	var flush func() error
+	switch strings.ToLower(format) {
+	case ExportFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(orderLineCSVHeader); err != nil {
+			return err
+		}
+		write = func(r OrderLineRecord) error {
+			return cw.Write([]string{
+				r.SalesOrderNumber,
+				r.WebOrderNumber,
+				r.OrderDate,
+				r.MouserPartNumber,
+				r.ManufacturerPartNumber,
+				strconv.Itoa(r.Quantity),
+				strconv.FormatFloat(r.UnitPrice, 'f', -1, 64),
+				strconv.FormatFloat(r.ExtPrice, 'f', -1, 64),
+				r.CurrencyCode,
+			})
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case ExportFormatJSON:
+		enc := json.NewEncoder(w)
+		write = func(r OrderLineRecord) error { return enc.Encode(r) }
+		flush = func() error { return nil }
+	default:
+		return fmt.Errorf("%w: unknown export format %q", ErrInvalidRequest, format)
+	}
+
+	ctx = withRateLimitWait(ctx)
+
+	var errs []error
+	var writeErr error
+	err := s.All(ctx, startDate, endDate, func(order OrderHistoryItem) bool {
+		detail, err := s.historyDetail(ctx, order)
+		if err != nil {
+			if ctx.Err() != nil {
+				return false
+			}
+			errs = append(errs, fmt.Errorf("mouser: order %s: %w", orderLabel(order), err))
+			return true
+		}
+
+		for _, record := range orderLineRecords(order, detail) {
+			if writeErr = write(record); writeErr != nil {
+				return false
+			}
+		}
+		writeErr = flush()
+		return writeErr == nil
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err == nil {
+		err = flush()
+	}
+	return errors.Join(append([]error{err}, errs...)...)
+}
+
+// orderLineRecords flattens an order's details into export records.
+func orderLineRecords(order OrderHistoryItem, detail *OrderDetailResponse) []OrderLineRecord {
+	salesOrder, webOrder := detail.SalesOrderId, detail.WebOrderId
+	if salesOrder == "" {
+		salesOrder = order.SalesOrderNumber
+	}
+	if webOrder == "" {
+		webOrder = order.WebOrderNumber
+	}
+
+	date := detail.OrderDate
+	if date == "" {
+		date = order.DateCreated
+	}
+	if t, ok := parseMouserDate(date); ok {
+		date = t.Format("2006-01-02")
+	}
+
+	records := make([]OrderLineRecord, len(detail.OrderLines))
+	for i, line := range detail.OrderLines {
+		records[i] = OrderLineRecord{
+			SalesOrderNumber:       salesOrder,
+			WebOrderNumber:         webOrder,
+			OrderDate:              date,
+			MouserPartNumber:       line.ProductInfo.MouserPartNumber,
+			ManufacturerPartNumber: line.ProductInfo.ManufacturerPartNumber,
+			Quantity:               line.Quantity,
+			UnitPrice:              line.UnitPrice,
+			ExtPrice:               line.ExtPrice,
+			CurrencyCode:           detail.CurrencyCode,
+		}
+	}
+	return records
+}
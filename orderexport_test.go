@@ -0,0 +1,86 @@
+package mouser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func exportHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/orderhistory/ByDateRange":
+			_, _ = w.Write([]byte(orderHistoryListResponse()))
+		case "/orderhistory/salesOrderNumber":
+			switch r.URL.Query().Get("salesOrderNumber") {
+			case "SO-001":
+				_, _ = w.Write([]byte(`{"Errors":[],"SalesOrderId":"SO-001","WebOrderId":"WO-001","OrderDate":"2025-01-15T10:30:00","CurrencyCode":"USD","OrderLines":[
+					{"Quantity":10,"UnitPrice":0.5,"ExtPrice":5,"ProductInfo":{"MouserPartNumber":"595-NE555P","ManufacturerPartNumber":"NE555P"}},
+					{"Quantity":2,"UnitPrice":1.25,"ExtPrice":2.5,"ProductInfo":{"MouserPartNumber":"511-LM317T","ManufacturerPartNumber":"LM317T"}}
+				]}`))
+			default:
+				_, _ = w.Write([]byte(`{"Errors":[{"Id":1,"Code":"NotFound","Message":"Order not found"}]}`))
+			}
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+}
+
+// TestExportLinesCSVMock tests exporting order lines as CSV, reporting
+// orders whose details cannot be fetched.
+func TestExportLinesCSVMock(t *testing.T) {
+	client := newTestClient(t, exportHandler(t))
+
+	var buf bytes.Buffer
+	err := client.OrderHistory.ExportLines(context.Background(), "2025-01-01", "2025-02-28", "CSV", &buf)
+	if err == nil || !strings.Contains(err.Error(), "SO-002") {
+		t.Errorf("expected an error for SO-002, got %v", err)
+	}
+
+	want := "SalesOrderNumber,WebOrderNumber,OrderDate,MouserPartNumber,ManufacturerPartNumber,Quantity,UnitPrice,ExtPrice,CurrencyCode\n" +
+		"SO-001,WO-001,2025-01-15,595-NE555P,NE555P,10,0.5,5,USD\n" +
+		"SO-001,WO-001,2025-01-15,511-LM317T,LM317T,2,1.25,2.5,USD\n"
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected CSV:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestExportLinesJSONMock tests exporting order lines as newline-delimited
+// JSON.
+func TestExportLinesJSONMock(t *testing.T) {
+	client := newTestClient(t, exportHandler(t))
+
+	var buf bytes.Buffer
+	_ = client.OrderHistory.ExportLines(context.Background(), "2025-01-01", "2025-01-31", ExportFormatJSON, &buf)
+
+	var records []OrderLineRecord
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var r OrderLineRecord
+		if err := dec.Decode(&r); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		records = append(records, r)
+	}
+	if len(records) != 2 || records[1].MouserPartNumber != "511-LM317T" || records[1].ExtPrice != 2.5 || records[1].CurrencyCode != "USD" {
+		t.Errorf("unexpected records: %+v", records)
+	}
+}
+
+// TestExportLinesInvalidFormat tests that unknown formats are rejected
+// before any request.
+func TestExportLinesInvalidFormat(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	err := client.OrderHistory.ExportLines(context.Background(), "2025-01-01", "2025-01-31", "xml", &bytes.Buffer{})
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+}
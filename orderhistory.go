@@ -2,7 +2,11 @@ package mouser
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
+	"strings"
+	"time"
 )
 
 // ByDateFilter retrieves order history filtered by a predefined date filter.
@@ -82,3 +86,205 @@ func (s *OrderHistoryService) ByWebOrderNumber(ctx context.Context, webOrderNumb
 
 	return &resp, nil
 }
+
+// FullOrder retrieves the detailed view of an order, with its line items,
+// status, addresses, and totals, from either a sales order number or a web
+// order number. It tries the number as a sales order number first and falls
+// back to a web order number if Mouser does not know it, so the caller need
+// not know which kind it has. Errors other than an unknown order are
+// returned without the fallback. If neither lookup finds the order, the
+// error wraps ErrNotFound and, if the last lookup failed, its error.
+//
+// Prefer FullOrder over Order.Details when displaying an order: Details
+// returns an OrderResponse, the order-creation view with lines and totals
+// but no status, order date, or payment and delivery details, and only
+// accepts the number returned when the order was created.
+func (s *OrderHistoryService) FullOrder(ctx context.Context, orderNumber string) (*OrderDetailResponse, error) {
+	if orderNumber == "" {
+		return nil, fmt.Errorf("%w: order number is required", ErrInvalidRequest)
+	}
+
+	detail, err := s.BySalesOrderNumber(ctx, orderNumber)
+	if !orderNotFound(detail, err) {
+		return detail, err
+	}
+
+	detail, err = s.ByWebOrderNumber(ctx, orderNumber)
+	if orderNotFound(detail, err) {
+		if err != nil {
+			return nil, fmt.Errorf("%w: order %s: %w", ErrNotFound, orderNumber, err)
+		}
+		return nil, fmt.Errorf("%w: order %s", ErrNotFound, orderNumber)
+	}
+	return detail, err
+}
+
+// orderNotFound reports whether an order lookup found nothing: a 404, API
+// errors that only reject the order number, or an empty response.
+func orderNotFound(detail *OrderDetailResponse, err error) bool {
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return true
+		}
+		var apiErrs APIErrors
+		if !errors.As(err, &apiErrs) || len(apiErrs) == 0 {
+			return false
+		}
+		for _, apiErr := range apiErrs {
+			if !isUnknownOrderNumber(apiErr) {
+				return false
+			}
+		}
+		return true
+	}
+	return detail.SalesOrderId == "" && detail.WebOrderId == "" && len(detail.OrderLines) == 0
+}
+
+// isUnknownOrderNumber reports whether apiErr rejects the order number
+// itself, such as InvalidSalesOrderNumber or an order not found message.
+func isUnknownOrderNumber(apiErr APIError) bool {
+	for _, s := range []string{strings.ToLower(apiErr.Code), strings.ToLower(apiErr.Message)} {
+		if strings.Contains(s, "order") && (strings.Contains(s, "invalid") || strings.Contains(s, "notfound") || strings.Contains(s, "not found")) {
+			return true
+		}
+	}
+	return false
+}
+
+// orderHistoryDateLayout is the date format All accepts and sends.
+const orderHistoryDateLayout = "2006-01-02"
+
+// All streams every order placed between startDate and endDate (inclusive,
+// formatted "2006-01-02") to callback. The callback should return true to
+// continue iterating, or false to stop.
+//
+// ByDateRange has no pagination, so All splits the range into calendar-month
+// sub-queries to keep each response small, issued in order and waiting for
+// the rate limiter rather than failing. Orders are de-duplicated by
+// SalesOrderNumber (or WebOrderNumber if it has none) in case the API
+// returns an order in two adjacent months. Like Search.All, All stops and
+// returns the context error once ctx is done; orders already delivered to
+// the callback are the partial result.
+func (s *OrderHistoryService) All(ctx context.Context, startDate, endDate string, callback func(OrderHistoryItem) bool) error {
+	start, err := time.Parse(orderHistoryDateLayout, startDate)
+	if err != nil {
+		return fmt.Errorf("%w: invalid start date %q", ErrInvalidRequest, startDate)
+	}
+	end, err := time.Parse(orderHistoryDateLayout, endDate)
+	if err != nil {
+		return fmt.Errorf("%w: invalid end date %q", ErrInvalidRequest, endDate)
+	}
+	if end.Before(start) {
+		return fmt.Errorf("%w: end date %s is before start date %s", ErrInvalidRequest, endDate, startDate)
+	}
+
+	ctx = withRateLimitWait(ctx)
+	seen := make(map[string]bool)
+
+	for from := start; !from.After(end); {
+		// The last day of from's month, or end if that comes first.
+		to := time.Date(from.Year(), from.Month()+1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		if to.After(end) {
+			to = end
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		history, err := s.ByDateRange(ctx, from.Format(orderHistoryDateLayout), to.Format(orderHistoryDateLayout))
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return err
+		}
+
+		for _, order := range history.OrderHistoryItems {
+			key := order.SalesOrderNumber
+			if key == "" {
+				key = "web:" + order.WebOrderNumber
+			}
+			if order.SalesOrderNumber != "" || order.WebOrderNumber != "" {
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			if !callback(order) {
+				return nil
+			}
+		}
+
+		from = to.AddDate(0, 0, 1)
+	}
+
+	return nil
+}
+
+// SpendSummary lists the orders placed between startDate and endDate, fetches
+// each order's details, and sums SummaryDetail.OrderTotal by currency and by
+// month. Detail requests wait for the rate limiter rather than failing.
+//
+// Orders whose details cannot be fetched are left out of the summary, and
+// their errors are joined into the returned error, so a partial summary may
+// be returned together with an error.
+func (s *OrderHistoryService) SpendSummary(ctx context.Context, startDate, endDate string) (SpendSummary, error) {
+	summary := SpendSummary{
+		ByCurrency: make(map[string]float64),
+		ByMonth:    make(map[string]map[string]float64),
+	}
+
+	history, err := s.ByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return summary, err
+	}
+
+	ctx = withRateLimitWait(ctx)
+
+	var errs []error
+	for _, order := range history.OrderHistoryItems {
+		detail, err := s.historyDetail(ctx, order)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("mouser: order %s: %w", orderLabel(order), err))
+			continue
+		}
+
+		month := "unknown"
+		if t, ok := parseMouserDate(detail.OrderDate); ok {
+			month = t.Format("2006-01")
+		} else if t, ok := parseMouserDate(order.DateCreated); ok {
+			month = t.Format("2006-01")
+		}
+
+		total := detail.SummaryDetail.OrderTotal
+		summary.OrderCount++
+		summary.ByCurrency[detail.CurrencyCode] += total
+		if summary.ByMonth[month] == nil {
+			summary.ByMonth[month] = make(map[string]float64)
+		}
+		summary.ByMonth[month][detail.CurrencyCode] += total
+	}
+
+	return summary, errors.Join(errs...)
+}
+
+// historyDetail fetches the details of an order history entry by its sales
+// order number, or by its web order number if it has none.
+func (s *OrderHistoryService) historyDetail(ctx context.Context, order OrderHistoryItem) (*OrderDetailResponse, error) {
+	switch {
+	case order.SalesOrderNumber != "":
+		return s.BySalesOrderNumber(ctx, order.SalesOrderNumber)
+	case order.WebOrderNumber != "":
+		return s.ByWebOrderNumber(ctx, order.WebOrderNumber)
+	}
+	return nil, fmt.Errorf("%w: order has no sales or web order number", ErrInvalidResponse)
+}
+
+// orderLabel returns the best available identifier for an order.
+func orderLabel(order OrderHistoryItem) string {
+	if order.SalesOrderNumber != "" {
+		return order.SalesOrderNumber
+	}
+	return order.WebOrderNumber
+}
@@ -1,5 +1,7 @@
 package mouser
 
+import "time"
+
 // DateFilterType defines date filter options for order history queries.
 type DateFilterType string
 
@@ -103,6 +105,50 @@ type OrderDetailResponse struct {
 	SummaryDetail OrderDetailSummary `json:"SummaryDetail"`
 }
 
+// Created parses DateCreated. It returns an error wrapping
+// ErrInvalidResponse if the date is missing or in an unknown format.
+func (o OrderHistoryItem) Created() (time.Time, error) {
+	return parseMouserDateField("DateCreated", o.DateCreated)
+}
+
+// Ordered parses OrderDate. It returns an error wrapping ErrInvalidResponse
+// if the date is missing or in an unknown format.
+func (r *OrderDetailResponse) Ordered() (time.Time, error) {
+	return parseMouserDateField("OrderDate", r.OrderDate)
+}
+
+// OrderStatus is a locale-independent order state, mapped from the numeric
+// OrderDetailResponse.OrderStatus code.
+type OrderStatus string
+
+const (
+	OrderStatusPending OrderStatus = "Pending"
+	OrderStatusShipped OrderStatus = "Shipped"
+	OrderStatusUnknown OrderStatus = "Unknown"
+)
+
+// OrderStatusFromCode maps a numeric Mouser order status code. Only code 3,
+// OrderStatusShipped, has been observed in responses; Mouser does not
+// document the others, so any other code, including 0, maps to
+// OrderStatusUnknown rather than a guess.
+func OrderStatusFromCode(code int) OrderStatus {
+	if code == 3 {
+		return OrderStatusShipped
+	}
+	return OrderStatusUnknown
+}
+
+// Status returns the order's status mapped from its numeric OrderStatus
+// code, which unlike OrderStatusName is not translated. An unrecognized code
+// on an order flagged IsPendingOrder is reported as OrderStatusPending.
+func (r *OrderDetailResponse) Status() OrderStatus {
+	status := OrderStatusFromCode(r.OrderStatus)
+	if status == OrderStatusUnknown && r.IsPendingOrder {
+		return OrderStatusPending
+	}
+	return status
+}
+
 // OrderDetailLine represents a line item in an order.
 type OrderDetailLine struct {
 	// Quantity is the ordered quantity.
@@ -225,3 +271,22 @@ type OrderLineActivity struct {
 	// Date is the activity date.
 	Date string `json:"Date"`
 }
+
+// Time parses Date. It returns an error wrapping ErrInvalidResponse if the
+// date is missing or in an unknown format.
+func (a OrderLineActivity) Time() (time.Time, error) {
+	return parseMouserDateField("Date", a.Date)
+}
+
+// SpendSummary aggregates order totals over a date range.
+type SpendSummary struct {
+	// OrderCount is the number of orders whose totals were included.
+	OrderCount int
+
+	// ByCurrency maps currency code to total spend.
+	ByCurrency map[string]float64
+
+	// ByMonth maps month ("2006-01") to currency code to total spend.
+	// Orders without a parseable date are grouped under "unknown".
+	ByMonth map[string]map[string]float64
+}
@@ -3,7 +3,9 @@ package mouser
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -256,6 +258,86 @@ func TestGetOrderByWebOrderNumberMock(t *testing.T) {
 	}
 }
 
+// TestFullOrderMock tests that FullOrder falls back from sales to web order
+// numbers and reports unknown orders as ErrNotFound.
+func TestFullOrderMock(t *testing.T) {
+	var paths []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		q := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case q.Get("salesOrderNumber") == "SO-001", q.Get("webOrderNumber") == "WO-001":
+			_, _ = w.Write([]byte(orderDetailResponse()))
+		case q.Has("salesOrderNumber"):
+			_, _ = w.Write([]byte(`{"Errors":[{"Code":"InvalidSalesOrderNumber","Message":"Invalid sales order number"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"Errors":[]}`))
+		}
+	})
+	client := newTestClient(t, handler)
+	ctx := context.Background()
+
+	detail, err := client.OrderHistory.FullOrder(ctx, "SO-001")
+	if err != nil || detail.SalesOrderId != "SO-001" {
+		t.Errorf("sales order: got %v, err=%v", detail, err)
+	}
+	if len(paths) != 1 {
+		t.Errorf("expected no fallback for a sales order number, got %v", paths)
+	}
+
+	paths = nil
+	detail, err = client.OrderHistory.FullOrder(ctx, "WO-001")
+	if err != nil || detail.SalesOrderId != "SO-001" {
+		t.Errorf("web order: got %v, err=%v", detail, err)
+	}
+	if len(paths) != 2 || paths[1] != "/orderhistory/webOrderNumber" {
+		t.Errorf("expected fallback to the web order number, got %v", paths)
+	}
+
+	if _, err := client.OrderHistory.FullOrder(ctx, "NOPE"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestFullOrderErrorsMock tests that FullOrder only falls back on unknown
+// order numbers and keeps the underlying error when both lookups fail.
+func TestFullOrderErrorsMock(t *testing.T) {
+	tests := []struct {
+		name      string
+		reply     string
+		wantPaths int
+		wantFound bool
+	}{
+		{"unauthorized", `{"Errors":[{"Code":"Unauthorized","Message":"Invalid API key"}]}`, 1, false},
+		{"order not found", `{"Errors":[{"Code":"OrderNotFound","Message":"Order not found"}]}`, 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths++
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.reply))
+			})
+			client := newTestClient(t, handler)
+
+			_, err := client.OrderHistory.FullOrder(context.Background(), "SO-404")
+			var apiErrs APIErrors
+			if !errors.As(err, &apiErrs) {
+				t.Errorf("expected the API errors to be kept, got %v", err)
+			}
+			if errors.Is(err, ErrNotFound) != tt.wantFound {
+				t.Errorf("errors.Is(err, ErrNotFound) = %v, want %v", !tt.wantFound, tt.wantFound)
+			}
+			if paths != tt.wantPaths {
+				t.Errorf("expected %d lookups, got %d", tt.wantPaths, paths)
+			}
+		})
+	}
+}
+
 // TestOrderHistoryErrorHandlingMock tests error handling for order history endpoints.
 func TestOrderHistoryErrorHandlingMock(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -270,6 +352,134 @@ func TestOrderHistoryErrorHandlingMock(t *testing.T) {
 	}
 }
 
+// TestSpendSummaryMock tests summing order totals by currency and month.
+func TestSpendSummaryMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/orderhistory/ByDateRange":
+			_, _ = w.Write([]byte(orderHistoryListResponse()))
+		case "/orderhistory/salesOrderNumber":
+			switch r.URL.Query().Get("salesOrderNumber") {
+			case "SO-001":
+				_, _ = w.Write([]byte(`{"Errors":[],"SalesOrderId":"SO-001","OrderDate":"2025-01-15","CurrencyCode":"USD","SummaryDetail":{"OrderTotal":125.50}}`))
+			case "SO-002":
+				_, _ = w.Write([]byte(`{"Errors":[],"SalesOrderId":"SO-002","OrderDate":"2025-02-01T09:00:00","CurrencyCode":"EUR","SummaryDetail":{"OrderTotal":80.25}}`))
+			}
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	client := newTestClient(t, handler)
+	summary, err := client.OrderHistory.SpendSummary(context.Background(), "2025-01-01", "2025-02-28")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if summary.OrderCount != 2 {
+		t.Errorf("expected 2 orders, got %d", summary.OrderCount)
+	}
+	if summary.ByCurrency["USD"] != 125.50 || summary.ByCurrency["EUR"] != 80.25 {
+		t.Errorf("unexpected currency totals: %v", summary.ByCurrency)
+	}
+	if summary.ByMonth["2025-01"]["USD"] != 125.50 || summary.ByMonth["2025-02"]["EUR"] != 80.25 {
+		t.Errorf("unexpected monthly totals: %v", summary.ByMonth)
+	}
+}
+
+// TestSpendSummaryPartialFailureMock tests that unfetchable orders are reported
+// while the remaining orders are still summed.
+func TestSpendSummaryPartialFailureMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/orderhistory/ByDateRange":
+			_, _ = w.Write([]byte(orderHistoryListResponse()))
+		case "/orderhistory/salesOrderNumber":
+			if r.URL.Query().Get("salesOrderNumber") == "SO-002" {
+				_, _ = w.Write([]byte(`{"Errors":[{"Id":1,"Code":"NotFound","Message":"Order not found"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"Errors":[],"SalesOrderId":"SO-001","OrderDate":"2025-01-15","CurrencyCode":"USD","SummaryDetail":{"OrderTotal":125.50}}`))
+		}
+	})
+
+	client := newTestClient(t, handler)
+	summary, err := client.OrderHistory.SpendSummary(context.Background(), "2025-01-01", "2025-02-28")
+	if err == nil || !strings.Contains(err.Error(), "SO-002") {
+		t.Fatalf("expected error naming SO-002, got %v", err)
+	}
+	if summary.OrderCount != 1 || summary.ByCurrency["USD"] != 125.50 {
+		t.Errorf("expected partial summary of SO-001, got %+v", summary)
+	}
+}
+
+// TestOrderHistoryAllMock tests month-sized sub-queries and de-duplication.
+func TestOrderHistoryAllMock(t *testing.T) {
+	var ranges []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, end := r.URL.Query().Get("startDate"), r.URL.Query().Get("endDate")
+		ranges = append(ranges, start+".."+end)
+
+		// Each month returns its own order plus SO-SHARED, which spans months.
+		month := start[:7]
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OrderHistoryResponse{
+			NumberOfOrders: 2,
+			OrderHistoryItems: []OrderHistoryItem{
+				{SalesOrderNumber: "SO-" + month, DateCreated: start},
+				{SalesOrderNumber: "SO-SHARED"},
+			},
+		})
+	})
+
+	client := newTestClient(t, handler)
+
+	var orders []string
+	err := client.OrderHistory.All(context.Background(), "2025-01-15", "2025-03-10", func(order OrderHistoryItem) bool {
+		orders = append(orders, order.SalesOrderNumber)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantRanges := "2025-01-15..2025-01-31,2025-02-01..2025-02-28,2025-03-01..2025-03-10"
+	if strings.Join(ranges, ",") != wantRanges {
+		t.Errorf("expected ranges %s, got %v", wantRanges, ranges)
+	}
+	wantOrders := "SO-2025-01,SO-SHARED,SO-2025-02,SO-2025-03"
+	if strings.Join(orders, ",") != wantOrders {
+		t.Errorf("expected orders %s, got %v", wantOrders, orders)
+	}
+
+	// Stopping early skips the remaining months.
+	ranges = nil
+	var count int
+	err = client.OrderHistory.All(context.Background(), "2025-01-15", "2025-03-10", func(OrderHistoryItem) bool {
+		count++
+		return false
+	})
+	if err != nil || count != 1 || len(ranges) != 1 {
+		t.Errorf("expected one order from one request, got %d orders, %d requests, err %v", count, len(ranges), err)
+	}
+}
+
+// TestOrderHistoryAllInvalidRange tests that bad dates are rejected before any request.
+func TestOrderHistoryAllInvalidRange(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+
+	for _, dates := range [][2]string{{"2025-13-01", "2025-12-31"}, {"2025-01-01", "01/31/2025"}, {"2025-02-01", "2025-01-01"}} {
+		err := client.OrderHistory.All(context.Background(), dates[0], dates[1], func(OrderHistoryItem) bool { return true })
+		if !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%v: expected ErrInvalidRequest, got %v", dates, err)
+		}
+	}
+}
+
 // TestOrderHistoryModelRoundtrip tests JSON marshal/unmarshal for order history models.
 func TestOrderHistoryModelRoundtrip(t *testing.T) {
 	original := OrderHistoryItem{
@@ -346,6 +556,29 @@ func TestOrderDetailModelRoundtrip(t *testing.T) {
 	}
 }
 
+// TestOrderDetailStatus tests mapping numeric status codes to OrderStatus.
+func TestOrderDetailStatus(t *testing.T) {
+	testCases := []struct {
+		detail OrderDetailResponse
+		want   OrderStatus
+	}{
+		{OrderDetailResponse{OrderStatus: 3, OrderStatusName: "Versandt"}, OrderStatusShipped},
+		{OrderDetailResponse{OrderStatus: 1}, OrderStatusUnknown},
+		{OrderDetailResponse{OrderStatus: 2, OrderStatusName: "En cours"}, OrderStatusUnknown},
+		{OrderDetailResponse{OrderStatus: 4}, OrderStatusUnknown},
+		{OrderDetailResponse{OrderStatus: 0}, OrderStatusUnknown},
+		{OrderDetailResponse{OrderStatus: 99, OrderStatusName: "Shipped"}, OrderStatusUnknown},
+		{OrderDetailResponse{OrderStatus: 0, IsPendingOrder: true}, OrderStatusPending},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.detail.Status(); got != tc.want {
+			t.Errorf("code %d (%q, pending=%v): expected %s, got %s",
+				tc.detail.OrderStatus, tc.detail.OrderStatusName, tc.detail.IsPendingOrder, tc.want, got)
+		}
+	}
+}
+
 // Integration tests - gated by MOUSER_API_KEY
 
 // TestIntegrationOrderHistoryByDateFilter tests order history query by date filter.
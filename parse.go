@@ -0,0 +1,156 @@
+package mouser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseQuantity extracts an integer quantity from a Mouser quantity string
+// such as "1234", "1,234", or "1,234 In Stock". It returns false if the
+// string does not start with a number.
+func parseQuantity(s string) (int, bool) {
+	digits := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case ch >= '0' && ch <= '9':
+			digits = append(digits, ch)
+		case (ch == ',' || ch == '.') && len(digits) > 0:
+			// Thousands separator; quantities are always whole numbers.
+		case ch == ' ' && len(digits) == 0:
+			// Leading whitespace.
+		default:
+			i = len(s)
+		}
+	}
+
+	if len(digits) == 0 {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(string(digits))
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// parseAmount parses a money amount such as "50", "1,250.00", or the
+// European "1.250,00". When both separators appear the last one is the
+// decimal point; a lone separator is decimal only if one or two digits
+// follow it.
+func parseAmount(s string) (float64, bool) {
+	s = strings.TrimRight(strings.TrimSpace(s), ".,")
+	if s == "" {
+		return 0, false
+	}
+
+	decimal := strings.LastIndexAny(s, ".,")
+	if decimal >= 0 {
+		if strings.ContainsAny(s[:decimal], ".,") && s[decimal] == s[strings.IndexAny(s, ".,")] {
+			// All separators are the same character: thousands separators.
+			decimal = -1
+		} else if !strings.ContainsAny(s[:decimal], ".,") && len(s)-decimal-1 > 2 {
+			decimal = -1
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch >= '0' && ch <= '9':
+			b.WriteByte(ch)
+		case i == decimal:
+			b.WriteByte('.')
+		}
+	}
+
+	f, err := strconv.ParseFloat(b.String(), 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// parsePrice parses a unit price such as "$1.00", "0,85 €", or "$0.00850".
+// Unlike parseAmount, a lone separator is always the decimal point, since
+// Mouser prices carry up to five decimal places but are never written
+// with only a thousands separator.
+func parsePrice(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if strings.Count(s, ".")+strings.Count(s, ",") == 1 {
+		s = strings.Replace(s, ",", ".", 1)
+		var b strings.Builder
+		for i := 0; i < len(s); i++ {
+			if ch := s[i]; ch >= '0' && ch <= '9' || ch == '.' {
+				b.WriteByte(ch)
+			}
+		}
+		f, err := strconv.ParseFloat(b.String(), 64)
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	}
+	return parseAmount(s)
+}
+
+// mouserDateLayouts are the date formats seen in Mouser API responses.
+var mouserDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006/01/02",
+	"1/2/2006 3:04:05 PM",
+	"1/2/2006",
+	// Locale variants seen on non-US accounts.
+	"02.01.2006 15:04:05",
+	"02.01.2006",
+	"02-Jan-2006",
+	"2 Jan 2006",
+	"Jan 2, 2006",
+}
+
+// parseMouserDate parses a date string from a Mouser API response, including
+// the "/Date(1642204800000)/" form. It returns false if no format matches.
+func parseMouserDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+
+	if strings.HasPrefix(s, "/Date(") && strings.HasSuffix(s, ")/") {
+		ms := strings.TrimSuffix(strings.TrimPrefix(s, "/Date("), ")/")
+		// Drop any timezone offset such as "+0000".
+		if i := strings.IndexAny(ms[1:], "+-"); i >= 0 {
+			ms = ms[:i+1]
+		}
+		n, err := strconv.ParseInt(ms, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.UnixMilli(n).UTC(), true
+	}
+
+	for _, layout := range mouserDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseMouserDateField is parseMouserDate for the exported date accessors.
+// It returns an error wrapping ErrInvalidResponse naming field if s is
+// empty or in no known format.
+func parseMouserDateField(field, s string) (time.Time, error) {
+	t, ok := parseMouserDate(s)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: unrecognized %s %q", ErrInvalidResponse, field, s)
+	}
+	return t, nil
+}
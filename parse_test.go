@@ -0,0 +1,145 @@
+package mouser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestParseQuantity tests parsing of Mouser quantity strings.
+func TestParseQuantity(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  int
+		ok    bool
+	}{
+		{"1234", 1234, true},
+		{"1,234", 1234, true},
+		{"1,234 In Stock", 1234, true},
+		{" 42", 42, true},
+		{"0", 0, true},
+		{"", 0, false},
+		{"None", 0, false},
+		{"On Order", 0, false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := parseQuantity(tc.input)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("parseQuantity(%q) = (%d, %v), want (%d, %v)", tc.input, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+// TestParseMouserDate tests parsing of the date formats Mouser returns.
+func TestParseMouserDate(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{"2025-01-15", "2025-01-15", true},
+		{"2025-01-15T10:30:00", "2025-01-15", true},
+		{"2025-01-15T10:30:00.123", "2025-01-15", true},
+		{"2025-01-15T10:30:00Z", "2025-01-15", true},
+		{"1/15/2025 10:30:00 AM", "2025-01-15", true},
+		{"1/15/2025", "2025-01-15", true},
+		{"/Date(1736937000000)/", "2025-01-15", true},
+		{"/Date(1736937000000+0000)/", "2025-01-15", true},
+		{"2025-01-15 10:30:00", "2025-01-15", true},
+		{"2025/01/15", "2025-01-15", true},
+		{"15.01.2025", "2025-01-15", true},
+		{"15.01.2025 10:30:00", "2025-01-15", true},
+		{"15-Jan-2025", "2025-01-15", true},
+		{"15 Jan 2025", "2025-01-15", true},
+		{"Jan 15, 2025", "2025-01-15", true},
+		{"", "", false},
+		{"yesterday", "", false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := parseMouserDate(tc.input)
+		if ok != tc.ok {
+			t.Errorf("parseMouserDate(%q) ok = %v, want %v", tc.input, ok, tc.ok)
+			continue
+		}
+		if ok && got.Format("2006-01-02") != tc.want {
+			t.Errorf("parseMouserDate(%q) = %s, want %s", tc.input, got.Format("2006-01-02"), tc.want)
+		}
+	}
+}
+
+// TestModelDateAccessors tests the exported date parsing methods.
+func TestModelDateAccessors(t *testing.T) {
+	created, err := OrderHistoryItem{DateCreated: "2025-01-15T10:30:00"}.Created()
+	if err != nil || created.Format("2006-01-02") != "2025-01-15" {
+		t.Errorf("Created() = %v, %v", created, err)
+	}
+
+	detail := &OrderDetailResponse{OrderDate: "1/15/2025"}
+	if ordered, err := detail.Ordered(); err != nil || ordered.Format("2006-01-02") != "2025-01-15" {
+		t.Errorf("Ordered() = %v, %v", ordered, err)
+	}
+
+	if at, err := (AvailabilityOnOrderObject{Date: "15.01.2025"}).Time(); err != nil || at.Day() != 15 {
+		t.Errorf("AvailabilityOnOrderObject.Time() = %v, %v", at, err)
+	}
+
+	_, err = OrderLineActivity{Date: "soon"}.Time()
+	if !errors.Is(err, ErrInvalidResponse) || !strings.Contains(err.Error(), `"soon"`) {
+		t.Errorf("expected ErrInvalidResponse naming the value, got %v", err)
+	}
+	if _, err := (OrderHistoryItem{}).Created(); !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("expected ErrInvalidResponse for a missing date, got %v", err)
+	}
+}
+
+// TestParseAmount tests parsing of amounts with mixed decimal separators.
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"50", 50, true},
+		{"50.00", 50, true},
+		{"50,00", 50, true},
+		{"1,250", 1250, true},
+		{"1,250.00", 1250, true},
+		{"1.250,00", 1250, true},
+		{"1,000,000", 1000000, true},
+		{"50.", 50, true},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseAmount(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseAmount(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// TestParsePrice tests parsing of prices with currency symbols and separators.
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"$1.00", 1, true},
+		{"1.0", 1, true},
+		{"$0.00850", 0.0085, true},
+		{"0,85 €", 0.85, true},
+		{"$1,250.00", 1250, true},
+		{"1.250,00 €", 1250, true},
+		{"$5", 5, true},
+		{"", 0, false},
+		{"N/A", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parsePrice(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parsePrice(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package mouser
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// LifecycleStatus is a normalized part lifecycle state.
+type LifecycleStatus string
+
+const (
+	LifecycleActive   LifecycleStatus = "Active"
+	LifecycleNRND     LifecycleStatus = "NRND"
+	LifecycleEOL      LifecycleStatus = "EOL"
+	LifecycleObsolete LifecycleStatus = "Obsolete"
+	LifecycleUnknown  LifecycleStatus = "Unknown"
+)
+
+// ParseLifecycleStatus normalizes a free-form Mouser lifecycle string.
+//
+// Known Mouser values and their mapping:
+//   - "New Product", "New at Mouser", "Active": LifecycleActive
+//   - "Not Recommended for New Designs", "NRND": LifecycleNRND
+//   - "End of Life", "EOL", "Last Time Buy": LifecycleEOL
+//   - "Obsolete": LifecycleObsolete
+//
+// Anything else, including the empty string Mouser returns for many
+// ordinary parts, maps to LifecycleUnknown.
+func ParseLifecycleStatus(s string) LifecycleStatus {
+	v := strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case v == "":
+		return LifecycleUnknown
+	case strings.Contains(v, "obsolete"):
+		return LifecycleObsolete
+	case strings.Contains(v, "not recommended") || v == "nrnd":
+		return LifecycleNRND
+	case strings.Contains(v, "end of life") || v == "eol" || strings.Contains(v, "last time buy"):
+		return LifecycleEOL
+	case strings.HasPrefix(v, "new") || v == "active":
+		return LifecycleActive
+	}
+	return LifecycleUnknown
+}
+
+// Lifecycle returns the part's normalized lifecycle status.
+func (p Part) Lifecycle() LifecycleStatus {
+	return ParseLifecycleStatus(p.LifecycleStatus)
+}
+
+// IsActive reports whether the part's lifecycle status is active.
+func (p Part) IsActive() bool {
+	return p.Lifecycle() == LifecycleActive
+}
+
+// IsObsolete reports whether the part is obsolete or at end of life.
+func (p Part) IsObsolete() bool {
+	l := p.Lifecycle()
+	return l == LifecycleObsolete || l == LifecycleEOL
+}
+
+// IsDiscontinuedBool interprets the IsDiscontinued string. Mouser sends
+// "true", "false", or an empty string; only "true" (case-insensitive)
+// reports true.
+func (p Part) IsDiscontinuedBool() bool {
+	return strings.EqualFold(strings.TrimSpace(p.IsDiscontinued), "true")
+}
+
+// inStockQuantity returns the parsed AvailabilityInStock, falling back to
+// the Availability message (e.g. "1,234 In Stock").
+func (p Part) inStockQuantity() (int, bool) {
+	if n, ok := parseQuantity(p.AvailabilityInStock); ok {
+		return n, true
+	}
+	return parseQuantity(p.Availability)
+}
+
+// AvailableByDate returns the earliest date by which qty units can be had,
+// counting current stock plus the AvailabilityOnOrder quantities scheduled
+// up to each date. If stock already covers qty (or qty <= 0) it returns
+// today's date (UTC). On-order entries whose dates cannot be parsed are
+// ignored. It returns false if stock plus all scheduled quantities fall
+// short.
+func (p Part) AvailableByDate(qty int) (time.Time, bool) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	available, _ := p.inStockQuantity()
+	if available >= qty {
+		return today, true
+	}
+
+	type scheduled struct {
+		date time.Time
+		qty  int
+	}
+	var orders []scheduled
+	for _, o := range p.AvailabilityOnOrder {
+		if date, ok := parseMouserDate(o.Date); ok && o.Quantity > 0 {
+			orders = append(orders, scheduled{date, o.Quantity})
+		}
+	}
+	sort.SliceStable(orders, func(i, j int) bool { return orders[i].date.Before(orders[j].date) })
+
+	for _, o := range orders {
+		available += o.qty
+		if available >= qty {
+			if o.date.Before(today) {
+				return today, true
+			}
+			return o.date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Datasheets returns the part's datasheet URLs, deduplicated in order of
+// appearance. Mouser occasionally fills DataSheetUrl with several pipe- or
+// comma-separated URLs.
+func (p Part) Datasheets() []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, u := range splitURLList(p.DataSheetUrl) {
+		if !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// splitURLList splits s on pipes, and on commas where every piece is a URL
+// in its own right, since commas may also appear within a URL.
+func splitURLList(s string) []string {
+	var urls []string
+	for _, field := range strings.Split(s, "|") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		pieces := strings.Split(field, ",")
+		for i := range pieces {
+			pieces[i] = strings.TrimSpace(pieces[i])
+			if !isAbsoluteURL(pieces[i]) {
+				pieces = []string{field}
+				break
+			}
+		}
+		urls = append(urls, pieces...)
+	}
+	return urls
+}
+
+// isAbsoluteURL reports whether s starts with an http(s) or protocol-relative
+// scheme.
+func isAbsoluteURL(s string) bool {
+	lower := strings.ToLower(s)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "//")
+}
+
+// LegalQuantity rounds qty up to the nearest quantity Mouser accepts for
+// the part: at least the minimum order quantity (Min) and a multiple of the
+// order multiple (Mult). Unparseable or missing Min and Mult values impose
+// no constraint.
+func (p Part) LegalQuantity(qty int) int {
+	if minimum, ok := parseQuantity(p.Min); ok && qty < minimum {
+		qty = minimum
+	}
+	if mult, ok := parseQuantity(p.Mult); ok && mult > 1 && qty%mult != 0 {
+		qty += mult - qty%mult
+	}
+	return qty
+}
+
+// ComplianceValue returns the value of the named ProductCompliance entry
+// (e.g. "USHTS", "ECCN"), matching the name case-insensitively.
+func (p Part) ComplianceValue(name string) (string, bool) {
+	for _, c := range p.ProductCompliance {
+		if strings.EqualFold(strings.TrimSpace(c.ComplianceName), strings.TrimSpace(name)) {
+			return c.ComplianceValue, true
+		}
+	}
+	return "", false
+}
+
+// IsRoHSCompliant interprets the part's RoHS status. The first result is the
+// compliance verdict and the second reports whether a verdict could be made.
+//
+// ROHSStatus values such as "RoHS Compliant" and "RoHS Compliant By
+// Exemption" count as compliant, while "Non-RoHS" and "Not Compliant" do not.
+// If ROHSStatus is empty or unrecognized, any ProductCompliance entry whose
+// name mentions RoHS is consulted instead.
+func (p Part) IsRoHSCompliant() (bool, bool) {
+	if compliant, known := parseRoHSValue(p.ROHSStatus); known {
+		return compliant, true
+	}
+
+	for _, c := range p.ProductCompliance {
+		if !strings.Contains(strings.ToLower(c.ComplianceName), "rohs") {
+			continue
+		}
+		if compliant, known := parseRoHSValue(c.ComplianceValue); known {
+			return compliant, true
+		}
+	}
+
+	return false, false
+}
+
+// parseRoHSValue interprets a RoHS status or compliance value.
+func parseRoHSValue(s string) (bool, bool) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case v == "":
+		return false, false
+	case strings.HasPrefix(v, "non") || strings.Contains(v, "not compliant") || v == "no" || v == "false":
+		return false, true
+	case strings.Contains(v, "not applicable"):
+		return false, false
+	case strings.Contains(v, "compliant") || v == "yes" || v == "true":
+		return true, true
+	}
+	return false, false
+}
+
+// HasSVHC reports whether the part lists any REACH Substances of Very High
+// Concern. Placeholder entries such as "No SVHC" or "None" are ignored.
+func (p Part) HasSVHC() bool {
+	return len(p.svhcSubstances()) > 0
+}
+
+// svhcSubstances returns the REACH_SVHC entries that name an actual substance.
+func (p Part) svhcSubstances() []string {
+	var substances []string
+	for _, s := range p.REACH_SVHC {
+		v := strings.ToLower(strings.TrimSpace(s))
+		if v == "" || v == "none" || v == "no svhc" || v == "n/a" {
+			continue
+		}
+		substances = append(substances, s)
+	}
+	return substances
+}
+
+// ComplianceSummary consolidates a part's compliance information for reporting.
+type ComplianceSummary struct {
+	// RoHSStatus is the raw ROHSStatus value.
+	RoHSStatus string
+
+	// RoHSCompliant is the RoHS verdict; only meaningful if RoHSKnown is true.
+	RoHSCompliant bool
+
+	// RoHSKnown reports whether a RoHS verdict could be made.
+	RoHSKnown bool
+
+	// SVHCCount is the number of REACH Substances of Very High Concern.
+	SVHCCount int
+
+	// SVHCSubstances lists the REACH SVHC entries, excluding placeholders.
+	SVHCSubstances []string
+
+	// Restricted reports whether the part carries a restriction message.
+	Restricted bool
+
+	// RestrictionMessage is the raw restriction message.
+	RestrictionMessage string
+
+	// Compliances maps compliance names (e.g. "USHTS", "ECCN") to values.
+	Compliances map[string]string
+}
+
+// ComplianceSummary consolidates ROHSStatus, REACH_SVHC, ProductCompliance,
+// and RestrictionMessage into a single struct.
+func (p Part) ComplianceSummary() ComplianceSummary {
+	compliant, known := p.IsRoHSCompliant()
+	substances := p.svhcSubstances()
+
+	summary := ComplianceSummary{
+		RoHSStatus:         p.ROHSStatus,
+		RoHSCompliant:      compliant,
+		RoHSKnown:          known,
+		SVHCCount:          len(substances),
+		SVHCSubstances:     substances,
+		Restricted:         strings.TrimSpace(p.RestrictionMessage) != "",
+		RestrictionMessage: p.RestrictionMessage,
+		Compliances:        make(map[string]string, len(p.ProductCompliance)),
+	}
+	for _, c := range p.ProductCompliance {
+		summary.Compliances[c.ComplianceName] = c.ComplianceValue
+	}
+
+	return summary
+}
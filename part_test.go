@@ -0,0 +1,257 @@
+package mouser
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseLifecycleStatus tests lifecycle string normalization.
+func TestParseLifecycleStatus(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  LifecycleStatus
+	}{
+		{"New Product", LifecycleActive},
+		{"New at Mouser", LifecycleActive},
+		{"Active", LifecycleActive},
+		{"Not Recommended for New Designs", LifecycleNRND},
+		{"NRND", LifecycleNRND},
+		{"End of Life", LifecycleEOL},
+		{"EOL", LifecycleEOL},
+		{"Obsolete", LifecycleObsolete},
+		{"  obsolete ", LifecycleObsolete},
+		{"", LifecycleUnknown},
+		{"Factory Special Order", LifecycleUnknown},
+	}
+
+	for _, tc := range testCases {
+		if got := ParseLifecycleStatus(tc.input); got != tc.want {
+			t.Errorf("ParseLifecycleStatus(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+// TestPartLifecycleHelpers tests the Part lifecycle convenience methods.
+func TestPartLifecycleHelpers(t *testing.T) {
+	active := Part{LifecycleStatus: "New Product"}
+	if !active.IsActive() || active.IsObsolete() {
+		t.Errorf("expected active part, got IsActive=%v IsObsolete=%v", active.IsActive(), active.IsObsolete())
+	}
+
+	obsolete := Part{LifecycleStatus: "Obsolete"}
+	if obsolete.IsActive() || !obsolete.IsObsolete() {
+		t.Errorf("expected obsolete part, got IsActive=%v IsObsolete=%v", obsolete.IsActive(), obsolete.IsObsolete())
+	}
+
+	eol := Part{LifecycleStatus: "End of Life"}
+	if !eol.IsObsolete() {
+		t.Error("expected EOL part to count as obsolete")
+	}
+}
+
+// TestPartIsDiscontinuedBool tests interpretation of the IsDiscontinued string.
+func TestPartIsDiscontinuedBool(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  bool
+	}{
+		{"true", true},
+		{"True", true},
+		{"false", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		p := Part{IsDiscontinued: tc.input}
+		if got := p.IsDiscontinuedBool(); got != tc.want {
+			t.Errorf("IsDiscontinuedBool(%q) = %v, want %v", tc.input, got, tc.want)
+		}
+	}
+}
+
+// TestPartComplianceValue tests lookup of named compliance entries.
+func TestPartComplianceValue(t *testing.T) {
+	p := Part{ProductCompliance: []ProductCompliance{
+		{ComplianceName: "USHTS", ComplianceValue: "8542310001"},
+		{ComplianceName: "ECCN", ComplianceValue: "EAR99"},
+	}}
+
+	if v, ok := p.ComplianceValue("eccn"); !ok || v != "EAR99" {
+		t.Errorf("ComplianceValue(eccn) = (%q, %v), want (EAR99, true)", v, ok)
+	}
+	if _, ok := p.ComplianceValue("TARIC"); ok {
+		t.Error("expected missing compliance entry to report false")
+	}
+}
+
+// TestPartIsRoHSCompliant tests interpretation of RoHS status sources.
+func TestPartIsRoHSCompliant(t *testing.T) {
+	testCases := []struct {
+		name          string
+		part          Part
+		wantCompliant bool
+		wantKnown     bool
+	}{
+		{"compliant", Part{ROHSStatus: "RoHS Compliant"}, true, true},
+		{"exemption", Part{ROHSStatus: "RoHS Compliant By Exemption"}, true, true},
+		{"non-rohs", Part{ROHSStatus: "Non-RoHS"}, false, true},
+		{"not compliant", Part{ROHSStatus: "Not Compliant"}, false, true},
+		{"not applicable", Part{ROHSStatus: "Not Applicable"}, false, false},
+		{"empty", Part{}, false, false},
+		{"from compliance entries", Part{ProductCompliance: []ProductCompliance{
+			{ComplianceName: "RoHS", ComplianceValue: "Compliant"},
+		}}, true, true},
+		{"status wins over entries", Part{ROHSStatus: "Non-RoHS", ProductCompliance: []ProductCompliance{
+			{ComplianceName: "RoHS", ComplianceValue: "Compliant"},
+		}}, false, true},
+	}
+
+	for _, tc := range testCases {
+		compliant, known := tc.part.IsRoHSCompliant()
+		if compliant != tc.wantCompliant || known != tc.wantKnown {
+			t.Errorf("%s: IsRoHSCompliant() = (%v, %v), want (%v, %v)", tc.name, compliant, known, tc.wantCompliant, tc.wantKnown)
+		}
+	}
+}
+
+// TestPartHasSVHC tests REACH SVHC detection.
+func TestPartHasSVHC(t *testing.T) {
+	if (Part{}).HasSVHC() {
+		t.Error("expected no SVHC for empty list")
+	}
+	if (Part{REACH_SVHC: []string{"No SVHC"}}).HasSVHC() {
+		t.Error("expected placeholder entry to be ignored")
+	}
+	if !(Part{REACH_SVHC: []string{"Lead"}}).HasSVHC() {
+		t.Error("expected SVHC for listed substance")
+	}
+}
+
+// TestPartComplianceSummary tests consolidation of all compliance sources.
+func TestPartComplianceSummary(t *testing.T) {
+	p := Part{
+		ROHSStatus: "RoHS Compliant By Exemption",
+		REACH_SVHC: []string{"Lead", "No SVHC", "Cadmium"},
+		ProductCompliance: []ProductCompliance{
+			{ComplianceName: "USHTS", ComplianceValue: "8542310001"},
+			{ComplianceName: "ECCN", ComplianceValue: "EAR99"},
+		},
+		RestrictionMessage: "This product may require additional documentation to export from the United States.",
+	}
+
+	s := p.ComplianceSummary()
+
+	if s.RoHSStatus != "RoHS Compliant By Exemption" || !s.RoHSCompliant || !s.RoHSKnown {
+		t.Errorf("unexpected RoHS fields: %+v", s)
+	}
+	if s.SVHCCount != 2 || len(s.SVHCSubstances) != 2 || s.SVHCSubstances[0] != "Lead" || s.SVHCSubstances[1] != "Cadmium" {
+		t.Errorf("unexpected SVHC fields: count=%d substances=%v", s.SVHCCount, s.SVHCSubstances)
+	}
+	if !s.Restricted || s.RestrictionMessage != p.RestrictionMessage {
+		t.Errorf("unexpected restriction fields: %v %q", s.Restricted, s.RestrictionMessage)
+	}
+	if len(s.Compliances) != 2 || s.Compliances["USHTS"] != "8542310001" || s.Compliances["ECCN"] != "EAR99" {
+		t.Errorf("unexpected compliances: %v", s.Compliances)
+	}
+
+	if empty := (Part{}).ComplianceSummary(); empty.RoHSKnown || empty.SVHCCount != 0 || empty.Restricted || len(empty.Compliances) != 0 {
+		t.Errorf("unexpected summary for empty part: %+v", empty)
+	}
+}
+
+// TestPartAvailableByDate tests combining stock with scheduled on-order quantities.
+func TestPartAvailableByDate(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	future := func(days int) string { return today.AddDate(0, 0, days).Format("2006-01-02") }
+
+	part := Part{
+		AvailabilityInStock: "100",
+		AvailabilityOnOrder: []AvailabilityOnOrderObject{
+			// Deliberately out of date order, with mixed formats.
+			{Quantity: 500, Date: today.AddDate(0, 0, 60).Format("1/2/2006")},
+			{Quantity: 200, Date: future(30)},
+			{Quantity: 1000, Date: "TBD"},
+		},
+	}
+
+	testCases := []struct {
+		qty  int
+		want time.Time
+		ok   bool
+	}{
+		{0, today, true},
+		{100, today, true},
+		{101, today.AddDate(0, 0, 30), true},
+		{300, today.AddDate(0, 0, 30), true},
+		{301, today.AddDate(0, 0, 60), true},
+		{800, today.AddDate(0, 0, 60), true},
+		{801, time.Time{}, false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := part.AvailableByDate(tc.qty)
+		if ok != tc.ok || !got.Equal(tc.want) {
+			t.Errorf("AvailableByDate(%d) = (%s, %v), want (%s, %v)",
+				tc.qty, got.Format("2006-01-02"), ok, tc.want.Format("2006-01-02"), tc.ok)
+		}
+	}
+
+	// On-order stock already due counts from today.
+	late := Part{AvailabilityOnOrder: []AvailabilityOnOrderObject{{Quantity: 10, Date: "2020-01-01"}}}
+	if got, ok := late.AvailableByDate(5); !ok || !got.Equal(today) {
+		t.Errorf("expected overdue on-order stock to count today, got (%s, %v)", got, ok)
+	}
+}
+
+// TestPartDatasheets tests splitting of multi-URL DataSheetUrl values.
+func TestPartDatasheets(t *testing.T) {
+	tests := []struct {
+		name string
+		part Part
+		want []string
+	}{
+		{"empty", Part{}, nil},
+		{"single", Part{DataSheetUrl: "https://example.com/a.pdf"}, []string{"https://example.com/a.pdf"}},
+		{"pipe separated", Part{DataSheetUrl: "https://example.com/a.pdf | https://example.com/b.pdf"},
+			[]string{"https://example.com/a.pdf", "https://example.com/b.pdf"}},
+		{"comma separated", Part{DataSheetUrl: "https://example.com/a.pdf,https://example.com/b.pdf"},
+			[]string{"https://example.com/a.pdf", "https://example.com/b.pdf"}},
+		{"comma within URL", Part{DataSheetUrl: "https://example.com/get?ids=1,2"},
+			[]string{"https://example.com/get?ids=1,2"}},
+		{"duplicates", Part{DataSheetUrl: "https://example.com/a.pdf | https://example.com/c.pdf | https://example.com/a.pdf"},
+			[]string{"https://example.com/a.pdf", "https://example.com/c.pdf"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.part.Datasheets()
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") || len(got) != len(tt.want) {
+				t.Errorf("Datasheets() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPartLegalQuantity tests rounding quantities up to the minimum and multiple.
+func TestPartLegalQuantity(t *testing.T) {
+	tests := []struct {
+		min, mult string
+		qty, want int
+	}{
+		{"1", "1", 7, 7},
+		{"10", "1", 3, 10},
+		{"1", "5", 7, 10},
+		{"1", "5", 10, 10},
+		{"2,500", "2,500", 100, 2500},
+		{"2500", "2500", 2501, 5000},
+		{"3", "2", 1, 4},
+		{"", "", 7, 7},
+	}
+	for _, tt := range tests {
+		p := Part{Min: tt.min, Mult: tt.mult}
+		if got := p.LegalQuantity(tt.qty); got != tt.want {
+			t.Errorf("Min=%q Mult=%q: LegalQuantity(%d) = %d, want %d", tt.min, tt.mult, tt.qty, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package mouser
+
+import (
+	"reflect"
+	"strings"
+)
+
+// partFieldComparers override reflect.DeepEqual for Part fields whose raw
+// values can differ without a real change.
+var partFieldComparers = map[string]func(a, b Part) bool{
+	"PriceBreaks": func(a, b Part) bool { return priceBreaksEqual(a.PriceBreaks, b.PriceBreaks) },
+}
+
+// Equal reports whether p and other describe the same part state, using
+// the same comparison as Diff.
+func (p Part) Equal(other Part) bool {
+	return len(p.Diff(other)) == 0
+}
+
+// Diff returns the names of the Part fields that differ between p and
+// other, in declaration order. Prices are compared as parsed numbers, so
+// "$1.00" and "1.0" are equal, and nil and empty slices are equal.
+func (p Part) Diff(other Part) []string {
+	a, b := reflect.ValueOf(p), reflect.ValueOf(other)
+	typ := a.Type()
+
+	var changed []string
+	for i := 0; i < typ.NumField(); i++ {
+		name := typ.Field(i).Name
+		var equal bool
+		if compare, ok := partFieldComparers[name]; ok {
+			equal = compare(p, other)
+		} else {
+			equal = fieldEqual(a.Field(i), b.Field(i))
+		}
+		if !equal {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
+
+// fieldEqual compares two struct field values, treating nil and empty
+// slices as equal.
+func fieldEqual(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Slice && a.Len() == 0 && b.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// priceBreaksEqual compares price breaks by quantity, currency, and parsed
+// price. Prices that cannot be parsed are compared as trimmed strings.
+func priceBreaksEqual(a, b []PriceBreak) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Quantity != b[i].Quantity || !strings.EqualFold(a[i].Currency, b[i].Currency) {
+			return false
+		}
+		if !priceEqual(a[i].Price, b[i].Price) {
+			return false
+		}
+	}
+	return true
+}
+
+// priceEqual compares two Mouser price strings numerically.
+func priceEqual(a, b string) bool {
+	x, okA := parsePrice(a)
+	y, okB := parsePrice(b)
+	if okA && okB {
+		return x == y
+	}
+	return strings.TrimSpace(a) == strings.TrimSpace(b)
+}
@@ -0,0 +1,69 @@
+package mouser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func diffTestPart() Part {
+	return Part{
+		MouserPartNumber:    "595-NE555DR",
+		Availability:        "1,234 In Stock",
+		AvailabilityInStock: "1234",
+		LifecycleStatus:     "Active",
+		PriceBreaks: []PriceBreak{
+			{Quantity: 1, Price: "$1.00", Currency: "USD"},
+			{Quantity: 100, Price: "$0.85", Currency: "USD"},
+		},
+	}
+}
+
+// TestPartEqual tests that identical parts compare equal.
+func TestPartEqual(t *testing.T) {
+	a, b := diffTestPart(), diffTestPart()
+	if !a.Equal(b) {
+		t.Errorf("expected equal parts, diff %v", a.Diff(b))
+	}
+}
+
+// TestPartDiff tests that changed fields are listed in declaration order.
+func TestPartDiff(t *testing.T) {
+	a, b := diffTestPart(), diffTestPart()
+	b.AvailabilityInStock = "0"
+	b.LifecycleStatus = "Obsolete"
+	b.PriceBreaks[1].Price = "$0.90"
+
+	want := []string{"AvailabilityInStock", "LifecycleStatus", "PriceBreaks"}
+	if got := a.Diff(b); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff = %v, want %v", got, want)
+	}
+	if a.Equal(b) {
+		t.Error("expected parts to differ")
+	}
+}
+
+// TestPartDiffPriceFormatting tests that prices are compared numerically.
+func TestPartDiffPriceFormatting(t *testing.T) {
+	a, b := diffTestPart(), diffTestPart()
+	b.PriceBreaks[0].Price = "1.0"
+	b.PriceBreaks[1].Price = " $0.850 "
+	b.PriceBreaks[1].Currency = "usd"
+
+	if diff := a.Diff(b); len(diff) != 0 {
+		t.Errorf("expected no diff for reformatted prices, got %v", diff)
+	}
+
+	b.PriceBreaks[1].Quantity = 50
+	if diff := a.Diff(b); !reflect.DeepEqual(diff, []string{"PriceBreaks"}) {
+		t.Errorf("expected PriceBreaks diff for quantity change, got %v", diff)
+	}
+}
+
+// TestPartDiffEmptySlices tests that nil and empty slices compare equal.
+func TestPartDiffEmptySlices(t *testing.T) {
+	a := Part{MouserPartNumber: "X"}
+	b := Part{MouserPartNumber: "X", InfoMessages: []string{}, PriceBreaks: []PriceBreak{}}
+	if !a.Equal(b) {
+		t.Errorf("expected nil and empty slices to be equal, diff %v", a.Diff(b))
+	}
+}
@@ -0,0 +1,199 @@
+package mouser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// partNumberResolveConcurrency bounds the concurrent part number searches
+// issued while resolving part numbers in bulk.
+const partNumberResolveConcurrency = 4
+
+// partNumberField selects one of a part's part numbers.
+type partNumberField func(Part) string
+
+func mouserPartNumber(p Part) string       { return p.MouserPartNumber }
+func manufacturerPartNumber(p Part) string { return p.ManufacturerPartNumber }
+
+// MapMPNToMouser maps manufacturer part numbers to Mouser part numbers. The
+// part numbers are looked up with exact part number searches of up to
+// MaxPartNumbers at a time, waiting for the rate limiter rather than failing
+// when it is exhausted. Where several parts match, an exact match is
+// preferred over a case-insensitive one, then the first in Mouser's order.
+//
+// Part numbers without a match are absent from the map and listed in an
+// *UnresolvedPartsError returned alongside the partial result. Duplicates
+// are looked up once; an empty part number is an ErrInvalidRequest.
+func (s *SearchService) MapMPNToMouser(ctx context.Context, mpns []string) (map[string]string, error) {
+	return s.mapPartNumbers(ctx, mpns, manufacturerPartNumber, mouserPartNumber)
+}
+
+// MapMouserToMPN maps Mouser part numbers to manufacturer part numbers. It
+// is the inverse of MapMPNToMouser and reports unmatched part numbers the
+// same way.
+func (s *SearchService) MapMouserToMPN(ctx context.Context, mouserPNs []string) (map[string]string, error) {
+	return s.mapPartNumbers(ctx, mouserPNs, mouserPartNumber, manufacturerPartNumber)
+}
+
+// AlternatePackagings returns the full Part record for each of part's
+// AlternatePackagings, such as the reel and cut tape versions of a part, in
+// the order Mouser lists them. The APMfrPN values are looked up with exact
+// part number searches of up to MaxPartNumbers at a time, run concurrently
+// and waiting for the rate limiter rather than failing when it is
+// exhausted. Alternates without a match are left out and listed in an
+// *UnresolvedPartsError returned alongside the rest.
+func (s *SearchService) AlternatePackagings(ctx context.Context, part Part) ([]Part, error) {
+	seen := make(map[string]bool, len(part.AlternatePackagings))
+	var partNumbers []string
+	for _, ap := range part.AlternatePackagings {
+		if pn := strings.TrimSpace(ap.APMfrPN); pn != "" && !seen[pn] {
+			seen[pn] = true
+			partNumbers = append(partNumbers, pn)
+		}
+	}
+	if len(partNumbers) == 0 {
+		return nil, nil
+	}
+
+	found, searchErr := s.resolveParts(withRateLimitWait(ctx), partNumbers,
+		[]partNumberField{manufacturerPartNumber, mouserPartNumber}, mouserPartNumber)
+
+	parts := make([]Part, 0, len(found))
+	var unresolved []string
+	for _, pn := range partNumbers {
+		if p, ok := found[pn]; ok {
+			parts = append(parts, p)
+		} else {
+			unresolved = append(unresolved, pn)
+		}
+	}
+	if len(unresolved) > 0 {
+		return parts, &UnresolvedPartsError{PartNumbers: unresolved, Err: searchErr}
+	}
+	return parts, nil
+}
+
+// mapPartNumbers implements MapMPNToMouser and MapMouserToMPN.
+func (s *SearchService) mapPartNumbers(ctx context.Context, partNumbers []string, from, to partNumberField) (map[string]string, error) {
+	seen := make(map[string]bool, len(partNumbers))
+	unique := make([]string, 0, len(partNumbers))
+	for _, pn := range partNumbers {
+		if strings.TrimSpace(pn) == "" {
+			return nil, fmt.Errorf("%w: empty part number", ErrInvalidRequest)
+		}
+		if !seen[pn] {
+			seen[pn] = true
+			unique = append(unique, pn)
+		}
+	}
+	sort.Strings(unique)
+
+	resolved, searchErr := s.resolvePartNumbers(withRateLimitWait(ctx), unique, []partNumberField{from}, to)
+
+	var unresolved []string
+	for _, pn := range unique {
+		if _, ok := resolved[pn]; !ok {
+			unresolved = append(unresolved, pn)
+		}
+	}
+	if len(unresolved) > 0 {
+		return resolved, &UnresolvedPartsError{PartNumbers: unresolved, Err: searchErr}
+	}
+	return resolved, nil
+}
+
+// resolvePartNumbers maps each part number to the to field of the best
+// matching part, as found by resolveParts. Parts whose to field is empty
+// never match.
+func (s *SearchService) resolvePartNumbers(ctx context.Context, partNumbers []string, from []partNumberField, to partNumberField) (map[string]string, error) {
+	parts, err := s.resolveParts(ctx, partNumbers, from, to)
+	resolved := make(map[string]string, len(parts))
+	for pn, part := range parts {
+		resolved[pn] = to(part)
+	}
+	return resolved, err
+}
+
+// resolveParts maps each part number to the best matching part, searching
+// in batches of MaxPartNumbers. A part matches if any of its from fields
+// equals the part number, ignoring case, and its required field is not
+// empty; an exact match wins over a case-insensitive one. Part numbers
+// without a match are absent from the result. The returned error joins the
+// errors of failed batches.
+func (s *SearchService) resolveParts(ctx context.Context, partNumbers []string, from []partNumberField, required partNumberField) (map[string]Part, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		resolved = make(map[string]Part, len(partNumbers))
+		errs     []error
+		sem      = make(chan struct{}, partNumberResolveConcurrency)
+	)
+
+	for start := 0; start < len(partNumbers); start += MaxPartNumbers {
+		batch := partNumbers[start:min(start+MaxPartNumbers, len(partNumbers))]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				mu.Lock()
+				errs = append(errs, ctx.Err())
+				mu.Unlock()
+				return
+			}
+
+			result, err := s.PartNumberSearch(ctx, PartNumberSearchOptions{
+				PartNumber:       strings.Join(batch, "|"),
+				PartSearchOption: PartSearchOptionExact,
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			for _, pn := range batch {
+				if part, ok := bestPartNumberMatch(pn, result.Parts, from, required); ok {
+					resolved[pn] = part
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return resolved, errors.Join(errs...)
+}
+
+// bestPartNumberMatch returns the first part with a non-empty required
+// field whose from fields match pn exactly, or else the first that matches
+// ignoring case.
+func bestPartNumberMatch(pn string, parts []Part, from []partNumberField, required partNumberField) (Part, bool) {
+	fallback := -1
+	for i, part := range parts {
+		if required(part) == "" {
+			continue
+		}
+		for _, field := range from {
+			candidate := field(part)
+			if candidate == pn {
+				return part, true
+			}
+			if fallback < 0 && strings.EqualFold(candidate, pn) {
+				fallback = i
+			}
+		}
+	}
+	if fallback < 0 {
+		return Part{}, false
+	}
+	return parts[fallback], true
+}
@@ -0,0 +1,138 @@
+package mouser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// partCatalogHandler answers exact part number searches from catalog,
+// matching either part number case-insensitively.
+func partCatalogHandler(t *testing.T, catalog []Part) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req partNumberSearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to parse search request: %v", err)
+			return
+		}
+
+		var parts []Part
+		for _, pn := range strings.Split(req.SearchByPartRequest.MouserPartNumber, "|") {
+			for _, part := range catalog {
+				if strings.EqualFold(part.ManufacturerPartNumber, pn) || strings.EqualFold(part.MouserPartNumber, pn) {
+					parts = append(parts, part)
+				}
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(searchResponse{SearchResults: SearchResult{NumberOfResult: len(parts), Parts: parts}})
+	})
+}
+
+var testPartCatalog = []Part{
+	{MouserPartNumber: "595-NE555P", ManufacturerPartNumber: "NE555P"},
+	{MouserPartNumber: "511-LM317T-LC", ManufacturerPartNumber: "lm317t"},
+	{MouserPartNumber: "511-LM317T", ManufacturerPartNumber: "LM317T"},
+	{MouserPartNumber: "621-1N4148W-F", ManufacturerPartNumber: "1N4148W-F"},
+}
+
+// TestMapMPNToMouserMock tests bulk MPN mapping with exact-match preference.
+func TestMapMPNToMouserMock(t *testing.T) {
+	client := newTestClient(t, partCatalogHandler(t, testPartCatalog))
+
+	mapped, err := client.Search.MapMPNToMouser(context.Background(),
+		[]string{"NE555P", "LM317T", "NE555P", "1N4148W-F", "NOPE-1", "595-NE555P"})
+
+	var unresolved *UnresolvedPartsError
+	if !errors.As(err, &unresolved) {
+		t.Fatalf("expected *UnresolvedPartsError, got %v", err)
+	}
+	// A Mouser part number is not a manufacturer part number.
+	if strings.Join(unresolved.PartNumbers, ",") != "595-NE555P,NOPE-1" {
+		t.Errorf("unexpected unresolved part numbers: %v", unresolved.PartNumbers)
+	}
+
+	want := map[string]string{
+		"NE555P":    "595-NE555P",
+		"LM317T":    "511-LM317T",
+		"1N4148W-F": "621-1N4148W-F",
+	}
+	if len(mapped) != len(want) {
+		t.Errorf("expected %d mappings, got %v", len(want), mapped)
+	}
+	for mpn, mouserPN := range want {
+		if mapped[mpn] != mouserPN {
+			t.Errorf("%s: expected %s, got %q", mpn, mouserPN, mapped[mpn])
+		}
+	}
+}
+
+// TestMapMouserToMPNMock tests the inverse mapping.
+func TestMapMouserToMPNMock(t *testing.T) {
+	client := newTestClient(t, partCatalogHandler(t, testPartCatalog))
+
+	mapped, err := client.Search.MapMouserToMPN(context.Background(), []string{"595-NE555P", "511-lm317t"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mapped["595-NE555P"] != "NE555P" {
+		t.Errorf("expected NE555P, got %q", mapped["595-NE555P"])
+	}
+	// No exact match, so the first case-insensitive match wins.
+	if mapped["511-lm317t"] != "LM317T" {
+		t.Errorf("expected LM317T, got %q", mapped["511-lm317t"])
+	}
+}
+
+// TestMapPartNumbersInvalid tests that empty part numbers are rejected.
+func TestMapPartNumbersInvalid(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+
+	if _, err := client.Search.MapMPNToMouser(context.Background(), []string{"NE555P", " "}); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest, got %v", err)
+	}
+	mapped, err := client.Search.MapMouserToMPN(context.Background(), nil)
+	if err != nil || len(mapped) != 0 {
+		t.Errorf("expected empty result for no input, got %v, %v", mapped, err)
+	}
+}
+
+// TestAlternatePackagingsMock tests expanding alternate packagings into full
+// parts, in Mouser's order, with misses reported.
+func TestAlternatePackagingsMock(t *testing.T) {
+	catalog := []Part{
+		{MouserPartNumber: "595-NE555DR", ManufacturerPartNumber: "NE555DR", PriceBreaks: []PriceBreak{{Quantity: 1, Price: "$0.42"}}},
+		{MouserPartNumber: "595-NE555DRG4", ManufacturerPartNumber: "NE555DRG4", PriceBreaks: []PriceBreak{{Quantity: 2500, Price: "$0.13"}}},
+		{MouserPartNumber: "595-NE555DRE4", ManufacturerPartNumber: "NE555DRE4"},
+	}
+	client := newTestClient(t, partCatalogHandler(t, catalog))
+
+	part := Part{
+		MouserPartNumber: "595-NE555DR",
+		AlternatePackagings: []AlternatePackaging{
+			{APMfrPN: "NE555DRG4"}, {APMfrPN: "NOPE-1"}, {APMfrPN: "NE555DRE4"}, {APMfrPN: "NE555DRG4"}, {APMfrPN: ""},
+		},
+	}
+	parts, err := client.Search.AlternatePackagings(context.Background(), part)
+
+	var unresolved *UnresolvedPartsError
+	if !errors.As(err, &unresolved) || strings.Join(unresolved.PartNumbers, ",") != "NOPE-1" {
+		t.Fatalf("expected NOPE-1 unresolved, got %v", err)
+	}
+	if len(parts) != 2 || parts[0].MouserPartNumber != "595-NE555DRG4" || parts[1].MouserPartNumber != "595-NE555DRE4" {
+		t.Fatalf("unexpected parts %+v", parts)
+	}
+	if len(parts[0].PriceBreaks) != 1 || parts[0].PriceBreaks[0].Quantity != 2500 {
+		t.Errorf("expected full part records, got %+v", parts[0])
+	}
+
+	none, err := client.Search.AlternatePackagings(context.Background(), Part{MouserPartNumber: "595-NE555DR"})
+	if err != nil || none != nil {
+		t.Errorf("expected nothing for a part without alternates, got %v, %v", none, err)
+	}
+}
@@ -3,17 +3,56 @@ package mouser
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
 	// MaxRecords is the maximum number of records per search request.
 	MaxRecords = 50
+
+	// MaxPartNumbers is the maximum number of pipe-separated part numbers
+	// per part number search request.
+	MaxPartNumbers = 10
 )
 
 // KeywordSearch searches for parts by keyword.
 // This uses the V1-compatible endpoint for broad keyword searches.
 func (s *SearchService) KeywordSearch(ctx context.Context, opts SearchOptions) (*SearchResult, error) {
+	result, _, err := s.KeywordSearchWithMeta(ctx, opts)
+	return result, err
+}
+
+// SearchMeta describes where a search result came from.
+type SearchMeta struct {
+	// FromCache reports whether the result was served from the cache.
+	FromCache bool
+
+	// FetchedAt is when the result was fetched from the API. For cached
+	// results it is only known with the default *MemoryCache; with a custom
+	// Cache it is the zero time.
+	FetchedAt time.Time
+
+	// Requests is the number of API responses the call received, including
+	// retried attempts. It is 0 for a cached result.
+	Requests int
+
+	// RateLimit is the rate limit snapshot taken as the call's last response
+	// headers were processed, so concurrent calls cannot skew it. For a
+	// cached result it is the snapshot at the time of the call.
+	RateLimit RateLimitStats
+}
+
+// KeywordSearchWithMeta is like KeywordSearch but also reports whether the
+// result came from the cache, when it was fetched, and how much rate limit
+// quota the call used and left, so a UI can show "results as of" and quota
+// accurately. When the call fails after reaching the API, Requests and
+// RateLimit are still reported.
+func (s *SearchService) KeywordSearchWithMeta(ctx context.Context, opts SearchOptions) (*SearchResult, SearchMeta, error) {
 	c := s.client
 
 	// Validate and set defaults
@@ -36,29 +75,44 @@ func (s *SearchService) KeywordSearch(ctx context.Context, opts SearchOptions) (
 
 	// Check cache
 	cacheKey := cacheKeyForSearch("keyword", req)
-	if cached, ok := c.getCached(cacheKey); ok {
+	if cached, ok := c.getCached(ctx, cacheKey); ok {
 		var result SearchResult
 		if err := json.Unmarshal(cached, &result); err == nil {
-			return &result, nil
+			meta := SearchMeta{FromCache: true, RateLimit: c.rateLimiter.Stats()}
+			if age, ok := c.CacheAge(cacheKey); ok {
+				meta.FetchedAt = time.Now().Add(-age)
+			}
+			result.StartingRecord = opts.StartingRecord
+			found, err := checkEmptyResult(&result, opts.ErrorOnEmpty, opts.Keyword)
+			return found, meta, err
 		}
 	}
 
 	var resp searchResponse
 	path := "/search/keyword"
-	if err := c.doRequest(ctx, "POST", path, req, &resp); err != nil {
-		return nil, err
+	callCtx, call := withCallStats(ctx)
+	err := c.doRequest(callCtx, "POST", path, req, &resp)
+	meta := SearchMeta{Requests: call.requests, RateLimit: call.rateLimit}
+	if !call.captured {
+		meta.RateLimit = c.rateLimiter.Stats()
+	}
+	if err != nil {
+		return nil, meta, err
 	}
+	meta.FetchedAt = time.Now()
 
 	if len(resp.Errors) > 0 {
-		return nil, APIErrors(resp.Errors)
+		return nil, meta, APIErrors(resp.Errors)
 	}
 
 	// Cache the result
 	if data, err := json.Marshal(resp.SearchResults); err == nil {
-		c.setCache(cacheKey, data, c.cacheConfig.SearchTTL)
+		c.setCache(cacheKey, data, c.cacheConfig.ttlForParts(c.cacheConfig.SearchTTL, resp.SearchResults.Parts...))
 	}
 
-	return &resp.SearchResults, nil
+	resp.SearchResults.StartingRecord = opts.StartingRecord
+	result, err := checkEmptyResult(&resp.SearchResults, opts.ErrorOnEmpty, opts.Keyword)
+	return result, meta, err
 }
 
 // PartNumberSearch searches for parts by part number.
@@ -66,6 +120,10 @@ func (s *SearchService) KeywordSearch(ctx context.Context, opts SearchOptions) (
 func (s *SearchService) PartNumberSearch(ctx context.Context, opts PartNumberSearchOptions) (*SearchResult, error) {
 	c := s.client
 
+	if err := validatePartNumbers(opts.PartNumber); err != nil {
+		return nil, err
+	}
+
 	req := partNumberSearchRequest{
 		SearchByPartRequest: searchByPartRequest{
 			MouserPartNumber:  opts.PartNumber,
@@ -75,10 +133,10 @@ func (s *SearchService) PartNumberSearch(ctx context.Context, opts PartNumberSea
 
 	// Check cache
 	cacheKey := cacheKeyForSearch("partnumber", req)
-	if cached, ok := c.getCached(cacheKey); ok {
+	if cached, ok := c.getCached(ctx, cacheKey); ok {
 		var result SearchResult
 		if err := json.Unmarshal(cached, &result); err == nil {
-			return &result, nil
+			return checkEmptyResult(&result, opts.ErrorOnEmpty, opts.PartNumber)
 		}
 	}
 
@@ -94,27 +152,42 @@ func (s *SearchService) PartNumberSearch(ctx context.Context, opts PartNumberSea
 
 	// Cache the result
 	if data, err := json.Marshal(resp.SearchResults); err == nil {
-		c.setCache(cacheKey, data, c.cacheConfig.SearchTTL)
+		c.setCache(cacheKey, data, c.cacheConfig.ttlForParts(c.cacheConfig.SearchTTL, resp.SearchResults.Parts...))
 	}
 
-	return &resp.SearchResults, nil
+	return checkEmptyResult(&resp.SearchResults, opts.ErrorOnEmpty, opts.PartNumber)
 }
 
 // KeywordAndManufacturerSearch searches for parts by keyword and manufacturer.
-// This is a V2 endpoint that supports pagination via PageNumber.
+// This is a V2 endpoint that supports pagination via PageNumber; the result's
+// StartingRecord is the offset of the returned page. Records above
+// MaxRecords are clamped, and negative Records or PageNumber values, or a
+// page beyond the API's 32-bit record offset, are an ErrInvalidRequest.
 func (s *SearchService) KeywordAndManufacturerSearch(ctx context.Context, opts KeywordAndManufacturerSearchOptions) (*SearchResult, error) {
 	c := s.client
 
 	// Validate and set defaults
-	if opts.Records <= 0 {
+	if opts.Records < 0 || opts.PageNumber < 0 {
+		return nil, fmt.Errorf("%w: Records and PageNumber must not be negative", ErrInvalidRequest)
+	}
+	if opts.Records == 0 {
 		opts.Records = 10
 	}
 	if opts.Records > MaxRecords {
 		opts.Records = MaxRecords
 	}
-	if opts.PageNumber <= 0 {
+	if opts.PageNumber == 0 {
 		opts.PageNumber = 1
 	}
+	startingRecord, err := pageStartingRecord(opts.PageNumber, opts.Records)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ResolveManufacturer && opts.ManufacturerName != "" {
+		if name, ok := s.ResolveManufacturer(ctx, opts.ManufacturerName); ok {
+			opts.ManufacturerName = name
+		}
+	}
 
 	req := keywordAndManufacturerSearchRequest{
 		SearchByKeywordMfrNameRequest: searchByKeywordMfrNameRequest{
@@ -129,10 +202,11 @@ func (s *SearchService) KeywordAndManufacturerSearch(ctx context.Context, opts K
 
 	// Check cache
 	cacheKey := cacheKeyForSearch("keyword+mfr", req)
-	if cached, ok := c.getCached(cacheKey); ok {
+	if cached, ok := c.getCached(ctx, cacheKey); ok {
 		var result SearchResult
 		if err := json.Unmarshal(cached, &result); err == nil {
-			return &result, nil
+			result.StartingRecord = startingRecord
+			return checkEmptyResult(&result, opts.ErrorOnEmpty, opts.Keyword)
 		}
 	}
 
@@ -148,10 +222,33 @@ func (s *SearchService) KeywordAndManufacturerSearch(ctx context.Context, opts K
 
 	// Cache the result
 	if data, err := json.Marshal(resp.SearchResults); err == nil {
-		c.setCache(cacheKey, data, c.cacheConfig.SearchTTL)
+		c.setCache(cacheKey, data, c.cacheConfig.ttlForParts(c.cacheConfig.SearchTTL, resp.SearchResults.Parts...))
+	}
+
+	resp.SearchResults.StartingRecord = startingRecord
+	return checkEmptyResult(&resp.SearchResults, opts.ErrorOnEmpty, opts.Keyword)
+}
+
+// KeywordAndManufacturerSearchPage is KeywordAndManufacturerSearch for the
+// 1-based page, overriding opts.PageNumber. It is the building block for
+// manual pagination: the result's StartingRecord and NumberOfResult tell
+// whether more pages follow.
+func (s *SearchService) KeywordAndManufacturerSearchPage(ctx context.Context, opts KeywordAndManufacturerSearchOptions, page int) (*SearchResult, error) {
+	if page < 1 {
+		return nil, fmt.Errorf("%w: page %d, pages start at 1", ErrInvalidRequest, page)
 	}
+	opts.PageNumber = page
+	return s.KeywordAndManufacturerSearch(ctx, opts)
+}
 
-	return &resp.SearchResults, nil
+// pageStartingRecord returns the 0-based offset of the first record on the
+// 1-based page of size records. Mouser reads the paging fields as 32-bit
+// integers, so pages whose last record lies beyond that are rejected.
+func pageStartingRecord(page, records int) (int, error) {
+	if page-1 > (math.MaxInt32-records)/records {
+		return 0, fmt.Errorf("%w: page %d of %d records is beyond the API's record range", ErrInvalidRequest, page, records)
+	}
+	return (page - 1) * records, nil
 }
 
 // PartNumberAndManufacturerSearch searches for parts by part number and manufacturer.
@@ -159,6 +256,16 @@ func (s *SearchService) KeywordAndManufacturerSearch(ctx context.Context, opts K
 func (s *SearchService) PartNumberAndManufacturerSearch(ctx context.Context, opts PartNumberAndManufacturerSearchOptions) (*SearchResult, error) {
 	c := s.client
 
+	if err := validatePartNumbers(opts.PartNumber); err != nil {
+		return nil, err
+	}
+
+	if opts.ResolveManufacturer && opts.ManufacturerName != "" {
+		if name, ok := s.ResolveManufacturer(ctx, opts.ManufacturerName); ok {
+			opts.ManufacturerName = name
+		}
+	}
+
 	req := partNumberAndManufacturerSearchRequest{
 		SearchByPartMfrNameRequest: searchByPartMfrNameRequest{
 			MouserPartNumber:  opts.PartNumber,
@@ -169,10 +276,10 @@ func (s *SearchService) PartNumberAndManufacturerSearch(ctx context.Context, opt
 
 	// Check cache
 	cacheKey := cacheKeyForSearch("partnumber+mfr", req)
-	if cached, ok := c.getCached(cacheKey); ok {
+	if cached, ok := c.getCached(ctx, cacheKey); ok {
 		var result SearchResult
 		if err := json.Unmarshal(cached, &result); err == nil {
-			return &result, nil
+			return checkEmptyResult(&result, opts.ErrorOnEmpty, opts.PartNumber)
 		}
 	}
 
@@ -188,10 +295,120 @@ func (s *SearchService) PartNumberAndManufacturerSearch(ctx context.Context, opt
 
 	// Cache the result
 	if data, err := json.Marshal(resp.SearchResults); err == nil {
-		c.setCache(cacheKey, data, c.cacheConfig.SearchTTL)
+		c.setCache(cacheKey, data, c.cacheConfig.ttlForParts(c.cacheConfig.SearchTTL, resp.SearchResults.Parts...))
 	}
 
-	return &resp.SearchResults, nil
+	return checkEmptyResult(&resp.SearchResults, opts.ErrorOnEmpty, opts.PartNumber)
+}
+
+// checkEmptyResult returns ErrNoResults, wrapped with the search term, if
+// errorOnEmpty is set and result has no matches. Otherwise it returns result.
+func checkEmptyResult(result *SearchResult, errorOnEmpty bool, term string) (*SearchResult, error) {
+	if errorOnEmpty && result.NumberOfResult == 0 && len(result.Parts) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoResults, term)
+	}
+	return result, nil
+}
+
+// validatePartNumbers returns a *TooManyPartsError if partNumber contains
+// more than MaxPartNumbers pipe-separated part numbers.
+func validatePartNumbers(partNumber string) error {
+	count := 0
+	for _, pn := range strings.Split(partNumber, "|") {
+		if strings.TrimSpace(pn) != "" {
+			count++
+		}
+	}
+	if count > MaxPartNumbers {
+		return &TooManyPartsError{Count: count, Limit: MaxPartNumbers}
+	}
+	return nil
+}
+
+// SmartSearch searches for a term that may be either a keyword or a part number.
+// It issues a keyword search and a part number search concurrently, merges the
+// results, and removes duplicates by Mouser part number. Parts whose Mouser or
+// manufacturer part number exactly matches the term (case-insensitive) are
+// ranked first, followed by the remaining part number matches and then the
+// keyword matches. opts.Keyword is replaced by term. opts.ErrorOnEmpty applies
+// to the merged result rather than to either search.
+//
+// Both requests go through the client's rate limiter. If both searches fail,
+// only the joined errors are returned. If one fails, the other's parts are
+// returned with a *PartialSearchError naming the failed search.
+// NumberOfResult is the number of merged parts.
+func (s *SearchService) SmartSearch(ctx context.Context, term string, opts SearchOptions) (*SearchResult, error) {
+	opts.Keyword = term
+	errorOnEmpty := opts.ErrorOnEmpty
+	opts.ErrorOnEmpty = false
+
+	var (
+		wg                  sync.WaitGroup
+		keywordResult       *SearchResult
+		partNumberResult    *SearchResult
+		keywordErr, partErr error
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		keywordResult, keywordErr = s.KeywordSearch(ctx, opts)
+	}()
+	go func() {
+		defer wg.Done()
+		partNumberResult, partErr = s.PartNumberSearch(ctx, PartNumberSearchOptions{
+			PartNumber:       term,
+			PartSearchOption: PartSearchOptionNone,
+		})
+	}()
+	wg.Wait()
+
+	var partialErr error
+	switch {
+	case keywordErr != nil && partErr != nil:
+		return nil, errors.Join(keywordErr, partErr)
+	case keywordErr != nil:
+		partialErr = &PartialSearchError{Search: "keyword", Err: keywordErr}
+	case partErr != nil:
+		partialErr = &PartialSearchError{Search: "part number", Err: partErr}
+	}
+
+	var exact, partMatches, keywordMatches []Part
+	seen := make(map[string]bool)
+	add := func(result *SearchResult, bucket *[]Part) {
+		if result == nil {
+			return
+		}
+		for _, part := range result.Parts {
+			if part.MouserPartNumber != "" {
+				if seen[part.MouserPartNumber] {
+					continue
+				}
+				seen[part.MouserPartNumber] = true
+			}
+			if strings.EqualFold(part.MouserPartNumber, term) || strings.EqualFold(part.ManufacturerPartNumber, term) {
+				exact = append(exact, part)
+				continue
+			}
+			*bucket = append(*bucket, part)
+		}
+	}
+	add(partNumberResult, &partMatches)
+	add(keywordResult, &keywordMatches)
+
+	parts := make([]Part, 0, len(exact)+len(partMatches)+len(keywordMatches))
+	parts = append(parts, exact...)
+	parts = append(parts, partMatches...)
+	parts = append(parts, keywordMatches...)
+
+	result, err := checkEmptyResult(&SearchResult{
+		NumberOfResult: len(parts),
+		Parts:          parts,
+	}, errorOnEmpty, term)
+	if partialErr != nil {
+		return result, errors.Join(partialErr, err)
+	}
+	return result, err
 }
 
 // ManufacturerList returns the list of all manufacturers in the Mouser catalog.
@@ -201,7 +418,7 @@ func (s *SearchService) ManufacturerList(ctx context.Context) (*ManufacturerList
 
 	// Check cache first (manufacturer list is mostly static)
 	cacheKey := cacheKeyForManufacturers()
-	if cached, ok := c.getCached(cacheKey); ok {
+	if cached, ok := c.getCached(ctx, cacheKey); ok {
 		var result ManufacturerListResult
 		if err := json.Unmarshal(cached, &result); err == nil {
 			return &result, nil
@@ -233,7 +450,7 @@ func (s *SearchService) PartDetails(ctx context.Context, partNumber string) (*Pa
 
 	// Check cache
 	cacheKey := cacheKeyForDetails(partNumber)
-	if cached, ok := c.getCached(cacheKey); ok {
+	if cached, ok := c.getCached(ctx, cacheKey); ok {
 		var result Part
 		if err := json.Unmarshal(cached, &result); err == nil {
 			return &result, nil
@@ -257,23 +474,154 @@ func (s *SearchService) PartDetails(ctx context.Context, partNumber string) (*Pa
 
 	// Cache the result
 	if data, err := json.Marshal(part); err == nil {
-		c.setCache(cacheKey, data, c.cacheConfig.DetailsTTL)
+		c.setCache(cacheKey, data, c.cacheConfig.ttlForParts(c.cacheConfig.DetailsTTL, part))
 	}
 
 	return &part, nil
 }
 
+// PartDetailsFuzzy is PartDetails with a fallback for slightly-off part
+// numbers, such as one missing a "/NOPB" suffix: if the exact lookup finds
+// nothing, it searches again without exact matching and returns Mouser's
+// first candidate. The boolean reports whether the part came from that
+// non-exact search, in which case callers may want to confirm it with the
+// user. Non-exact results are not cached as part details.
+func (s *SearchService) PartDetailsFuzzy(ctx context.Context, partNumber string) (*Part, bool, error) {
+	part, err := s.PartDetails(ctx, partNumber)
+	if err == nil {
+		return part, false, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, false, err
+	}
+
+	result, err := s.PartNumberSearch(ctx, PartNumberSearchOptions{
+		PartNumber:       partNumber,
+		Records:          1,
+		PartSearchOption: PartSearchOptionNone,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if len(result.Parts) == 0 {
+		return nil, false, fmt.Errorf("%w: %s", ErrNotFound, partNumber)
+	}
+	return &result.Parts[0], true, nil
+}
+
+// ProductDetail retrieves a single part by its Mouser part number. Mouser
+// has no dedicated product-detail endpoint, so this runs an exact part
+// number search and, unlike PartDetails, does not take the first result
+// blindly: a result whose Mouser part number matches exactly wins, and
+// otherwise the search must return exactly one part. It returns ErrNotFound
+// when nothing matches and an *AmbiguousPartError when several parts do.
+// Use PartDetails or PartDetailsWithManufacturer to look up manufacturer
+// part numbers.
+func (s *SearchService) ProductDetail(ctx context.Context, mouserPartNumber string) (*Part, error) {
+	c := s.client
+
+	cacheKey := cacheKeyForProductDetail(mouserPartNumber)
+	if cached, ok := c.getCached(ctx, cacheKey); ok {
+		var result Part
+		if err := json.Unmarshal(cached, &result); err == nil {
+			return &result, nil
+		}
+	}
+
+	result, err := s.PartNumberSearch(ctx, PartNumberSearchOptions{
+		PartNumber:       mouserPartNumber,
+		Records:          MaxRecords,
+		PartSearchOption: PartSearchOptionExact,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Part
+	for _, p := range result.Parts {
+		if strings.EqualFold(p.MouserPartNumber, mouserPartNumber) {
+			matches = append(matches, p)
+		}
+	}
+	if len(matches) == 0 {
+		matches = result.Parts
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, mouserPartNumber)
+	case 1:
+	default:
+		candidates := make([]string, len(matches))
+		for i, p := range matches {
+			candidates[i] = p.MouserPartNumber
+		}
+		return nil, &AmbiguousPartError{PartNumber: mouserPartNumber, Candidates: candidates}
+	}
+
+	part := matches[0]
+	if data, err := json.Marshal(part); err == nil {
+		c.setCache(cacheKey, data, c.cacheConfig.ttlForParts(c.cacheConfig.DetailsTTL, part))
+	}
+	return &part, nil
+}
+
+// SuggestedReplacement looks up the full details of the part Mouser suggests
+// in place of an obsolete part's SuggestedReplacement. The replacement is
+// looked up from the same manufacturer first, then with PartDetails, since
+// replacements sometimes come from another manufacturer. It returns
+// ErrNotFound if the part has no suggested replacement or the replacement
+// cannot be found.
+func (s *SearchService) SuggestedReplacement(ctx context.Context, part Part) (*Part, error) {
+	replacement := strings.TrimSpace(part.SuggestedReplacement)
+	if replacement == "" {
+		return nil, fmt.Errorf("%w: %s has no suggested replacement", ErrNotFound, part.MouserPartNumber)
+	}
+
+	if part.Manufacturer != "" {
+		found, err := s.PartDetailsWithManufacturer(ctx, replacement, part.Manufacturer)
+		if !errors.Is(err, ErrNotFound) {
+			return found, err
+		}
+	}
+	return s.PartDetails(ctx, replacement)
+}
+
+// LookupSource identifies how a part lookup found its result.
+type LookupSource string
+
+const (
+	// LookupSourceCache means the result was served from the cache.
+	LookupSourceCache LookupSource = "cache"
+	// LookupSourceManufacturerSearch means PartNumberAndManufacturerSearch
+	// found the part.
+	LookupSourceManufacturerSearch LookupSource = "manufacturer-search"
+	// LookupSourcePartNumberFallback means the part was found by the
+	// PartNumberSearch fallback enabled with WithManufacturerSearchFallback.
+	LookupSourcePartNumberFallback LookupSource = "partnumber-fallback"
+)
+
 // PartDetailsWithManufacturer retrieves detailed information for a specific part from a specific manufacturer.
 // This provides more precise matching than PartDetails.
 func (s *SearchService) PartDetailsWithManufacturer(ctx context.Context, partNumber, manufacturerName string) (*Part, error) {
+	part, _, err := s.PartDetailsWithManufacturerSource(ctx, partNumber, manufacturerName)
+	return part, err
+}
+
+// PartDetailsWithManufacturerSource is like PartDetailsWithManufacturer but
+// also reports which lookup produced the part. If the client was created
+// WithManufacturerSearchFallback(true) and the manufacturer-filtered search
+// finds nothing, an exact PartNumberSearch is filtered by manufacturer name,
+// ignoring case and corporate suffixes.
+func (s *SearchService) PartDetailsWithManufacturerSource(ctx context.Context, partNumber, manufacturerName string) (*Part, LookupSource, error) {
 	c := s.client
 
 	// Check cache
 	cacheKey := cacheKeyForDetails(manufacturerName + ":" + partNumber)
-	if cached, ok := c.getCached(cacheKey); ok {
+	if cached, ok := c.getCached(ctx, cacheKey); ok {
 		var result Part
 		if err := json.Unmarshal(cached, &result); err == nil {
-			return &result, nil
+			return &result, LookupSourceCache, nil
 		}
 	}
 
@@ -283,33 +631,69 @@ func (s *SearchService) PartDetailsWithManufacturer(ctx context.Context, partNum
 		PartSearchOption: PartSearchOptionExact,
 	})
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	if len(result.Parts) == 0 {
-		return nil, fmt.Errorf("%w: %s (%s)", ErrNotFound, partNumber, manufacturerName)
+	source := LookupSourceManufacturerSearch
+	parts := result.Parts
+	if len(parts) == 0 && c.manufacturerSearchFallback {
+		fallback, err := s.PartNumberSearch(ctx, PartNumberSearchOptions{
+			PartNumber:       partNumber,
+			PartSearchOption: PartSearchOptionExact,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		for _, p := range fallback.Parts {
+			if sameManufacturer(p.Manufacturer, manufacturerName) {
+				parts = append(parts, p)
+			}
+		}
+		source = LookupSourcePartNumberFallback
 	}
 
-	part := result.Parts[0]
+	if len(parts) == 0 {
+		return nil, "", fmt.Errorf("%w: %s (%s)", ErrNotFound, partNumber, manufacturerName)
+	}
+
+	part := parts[0]
 
 	// Cache the result
 	if data, err := json.Marshal(part); err == nil {
-		c.setCache(cacheKey, data, c.cacheConfig.DetailsTTL)
+		c.setCache(cacheKey, data, c.cacheConfig.ttlForParts(c.cacheConfig.DetailsTTL, part))
 	}
 
-	return &part, nil
+	return &part, source, nil
 }
 
 // All iterates through all pages of search results, calling the callback for each part.
 // The callback should return true to continue iterating, or false to stop.
 // This is useful for processing large result sets without manually managing pagination.
+//
+// The context deadline bounds the whole iteration: once it passes, All stops
+// before fetching another page and returns the context error. Parts delivered
+// to the callback before that point are the partial result. To cap the
+// iteration's own duration with a distinct error, see ContextWithSearchBudget.
+//
+// Each page is cached under its own offset, so repeating an iteration with
+// the same options within SearchTTL costs no requests.
 func (s *SearchService) All(ctx context.Context, opts SearchOptions, callback func(Part) bool) error {
 	opts.Records = MaxRecords
 	opts.StartingRecord = 0
 
+	ctx, cancel := withSearchBudget(ctx)
+	defer cancel()
+
 	for {
+		if ctx.Err() != nil {
+			return iterationErr(ctx)
+		}
+
 		result, err := s.KeywordSearch(ctx, opts)
 		if err != nil {
+			if ctx.Err() != nil {
+				return iterationErr(ctx)
+			}
 			return err
 		}
 
@@ -319,6 +703,9 @@ func (s *SearchService) All(ctx context.Context, opts SearchOptions, callback fu
 			}
 		}
 
+		// Only an empty first page means there are no results
+		opts.ErrorOnEmpty = false
+
 		// Check if we've retrieved all results
 		if len(result.Parts) < MaxRecords || opts.StartingRecord+len(result.Parts) >= result.NumberOfResult {
 			break
@@ -333,13 +720,25 @@ func (s *SearchService) All(ctx context.Context, opts SearchOptions, callback fu
 // AllByManufacturer iterates through all pages of keyword+manufacturer search results,
 // calling the callback for each part. The callback should return true to continue iterating,
 // or false to stop. This uses the V2 PageNumber-based pagination.
+// Like All, it honors the context deadline and search budget across the
+// whole iteration, and its pages are cached individually.
 func (s *SearchService) AllByManufacturer(ctx context.Context, opts KeywordAndManufacturerSearchOptions, callback func(Part) bool) error {
 	opts.Records = MaxRecords
 	opts.PageNumber = 1
 
+	ctx, cancel := withSearchBudget(ctx)
+	defer cancel()
+
 	for {
+		if ctx.Err() != nil {
+			return iterationErr(ctx)
+		}
+
 		result, err := s.KeywordAndManufacturerSearch(ctx, opts)
 		if err != nil {
+			if ctx.Err() != nil {
+				return iterationErr(ctx)
+			}
 			return err
 		}
 
@@ -349,6 +748,9 @@ func (s *SearchService) AllByManufacturer(ctx context.Context, opts KeywordAndMa
 			}
 		}
 
+		// Only an empty first page means there are no results
+		opts.ErrorOnEmpty = false
+
 		// Check if we've retrieved all results
 		if len(result.Parts) < MaxRecords {
 			break
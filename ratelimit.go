@@ -34,12 +34,15 @@ type RateLimiter struct {
 
 	// Server-indicated backoff (from Retry-After header)
 	blockedUntil time.Time
+
+	clock Clock
 }
 
 // NewRateLimiter creates a new RateLimiter with the specified limits.
 func NewRateLimiter(requestsPerMinute, requestsPerDay int) *RateLimiter {
 	now := time.Now()
 	return &RateLimiter{
+		clock:             realClock{},
 		requestsPerMinute: requestsPerMinute,
 		minuteTokens:      requestsPerMinute,
 		lastMinuteReset:   now,
@@ -49,12 +52,34 @@ func NewRateLimiter(requestsPerMinute, requestsPerDay int) *RateLimiter {
 	}
 }
 
+// setClock makes r tell time with clock and restarts its windows at the
+// clock's current time, as if r had just been created.
+func (r *RateLimiter) setClock(clock Clock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.clock = clockOrReal(clock)
+	now := r.clock.Now()
+	r.minuteTokens = r.requestsPerMinute
+	r.lastMinuteReset = now
+	r.dailyTokens = r.requestsPerDay
+	r.lastDayReset = now
+	r.blockedUntil = time.Time{}
+}
+
+// now returns the current time on r's clock.
+func (r *RateLimiter) now() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.clock.Now()
+}
+
 // Wait blocks until a request can be made or the context is cancelled.
 // It returns an error if the daily limit is exceeded or the context is cancelled.
 func (r *RateLimiter) Wait(ctx context.Context) error {
 	for {
 		r.mu.Lock()
-		now := time.Now()
+		now := r.clock.Now()
 
 		// Check server-indicated backoff first
 		if now.Before(r.blockedUntil) {
@@ -64,7 +89,7 @@ func (r *RateLimiter) Wait(ctx context.Context) error {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case <-time.After(waitTime):
+			case <-r.clock.After(waitTime):
 				continue
 			}
 		}
@@ -104,7 +129,7 @@ func (r *RateLimiter) Wait(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-time.After(waitTime):
+		case <-r.clock.After(waitTime):
 			// Continue loop to try again
 		}
 	}
@@ -116,13 +141,14 @@ func (r *RateLimiter) Allow() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	// Check server-indicated backoff
 	if now.Before(r.blockedUntil) {
 		return &RateLimitError{
 			Limit:     r.requestsPerMinute,
 			Remaining: 0,
+			clock:     r.clock,
 			ResetAt:   r.blockedUntil,
 			Type:      "minute",
 		}
@@ -145,6 +171,7 @@ func (r *RateLimiter) Allow() error {
 		return &RateLimitError{
 			Limit:     r.requestsPerDay,
 			Remaining: 0,
+			clock:     r.clock,
 			ResetAt:   r.lastDayReset.Add(24 * time.Hour),
 			Type:      "day",
 		}
@@ -155,6 +182,7 @@ func (r *RateLimiter) Allow() error {
 		return &RateLimitError{
 			Limit:     r.requestsPerMinute,
 			Remaining: 0,
+			clock:     r.clock,
 			ResetAt:   r.lastMinuteReset.Add(time.Minute),
 			Type:      "minute",
 		}
@@ -165,6 +193,43 @@ func (r *RateLimiter) Allow() error {
 	return nil
 }
 
+// WaitForQuota blocks until at least n requests fit in the daily budget or
+// the context is cancelled. It does not consume any tokens. It returns an
+// error wrapping ErrDailyLimitExceeded immediately if n exceeds the daily
+// limit, since no amount of waiting would make room for it.
+func (r *RateLimiter) WaitForQuota(ctx context.Context, n int) error {
+	for {
+		r.mu.Lock()
+		now := r.clock.Now()
+
+		if n > r.requestsPerDay {
+			limit := r.requestsPerDay
+			r.mu.Unlock()
+			return fmt.Errorf("%w: %d requests exceed the daily limit of %d", ErrDailyLimitExceeded, n, limit)
+		}
+
+		// Reset daily tokens if a day has passed
+		if now.Sub(r.lastDayReset) >= 24*time.Hour {
+			r.dailyTokens = r.requestsPerDay
+			r.lastDayReset = now
+		}
+
+		if r.dailyTokens >= n {
+			r.mu.Unlock()
+			return nil
+		}
+
+		waitTime := r.lastDayReset.Add(24 * time.Hour).Sub(now)
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-r.clock.After(waitTime):
+		}
+	}
+}
+
 // Deprecated: Use Allow instead.
 // TryAcquire attempts to acquire a rate limit token without blocking.
 // Returns true if successful, false if rate limited.
@@ -172,7 +237,7 @@ func (r *RateLimiter) TryAcquire() (bool, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	// Check server-indicated backoff
 	if now.Before(r.blockedUntil) {
@@ -221,7 +286,7 @@ func (r *RateLimiter) UpdateFromResponse(retryAfterSeconds int) {
 		retryAfterSeconds = 300
 	}
 
-	blockedUntil := time.Now().Add(time.Duration(retryAfterSeconds) * time.Second)
+	blockedUntil := r.clock.Now().Add(time.Duration(retryAfterSeconds) * time.Second)
 	if blockedUntil.After(r.blockedUntil) {
 		r.blockedUntil = blockedUntil
 	}
@@ -242,22 +307,45 @@ type RateLimitStats struct {
 
 // UpdateFromHeaders syncs rate limiter state from API response headers.
 // If present, X-RateLimit-* headers sync the daily limit and X-BurstLimit-*
-// headers sync the minute limit.
+// headers sync the minute limit. X-BurstLimit-Reset and X-RateLimit-Reset
+// align the local windows with the server's, so Wait and Stats use the
+// server-provided reset instants. When X-BurstLimit-Remaining reaches zero
+// without a reset header, Wait blocks until the next minute boundary.
 func (r *RateLimiter) UpdateFromHeaders(headers http.Header) {
-	if headers == nil {
-		return
-	}
+	r.syncHeaders(headers)
+}
 
+// syncHeaders is UpdateFromHeaders, returning the stats as of the update
+// under the same lock so that no other request can slip in between.
+func (r *RateLimiter) syncHeaders(headers http.Header) RateLimitStats {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if headers != nil {
+		r.applyHeaders(headers)
+	}
+	return r.stats()
+}
+
+// applyHeaders implements UpdateFromHeaders. The caller must hold r.mu.
+func (r *RateLimiter) applyHeaders(headers http.Header) {
+	now := r.clock.Now()
+
 	// Sync burst/minute limit
 	if limit := headerInt(headers, "X-BurstLimit-Limit"); limit > 0 {
 		r.requestsPerMinute = limit
 	}
-	if remaining := headerInt(headers, "X-BurstLimit-Remaining"); remaining >= 0 {
+	remaining := headerInt(headers, "X-BurstLimit-Remaining")
+	if remaining >= 0 {
 		r.minuteTokens = remaining
 	}
+	if reset, ok := headerReset(headers, "X-BurstLimit-Reset", now); ok {
+		r.lastMinuteReset = reset.Add(-time.Minute)
+	} else if remaining == 0 {
+		// The server says the burst window is spent; block until the next
+		// minute boundary rather than the end of the local window.
+		r.lastMinuteReset = now.Truncate(time.Minute)
+	}
 
 	// Sync day limit
 	if limit := headerInt(headers, "X-RateLimit-Limit"); limit > 0 {
@@ -266,6 +354,9 @@ func (r *RateLimiter) UpdateFromHeaders(headers http.Header) {
 	if remaining := headerInt(headers, "X-RateLimit-Remaining"); remaining >= 0 {
 		r.dailyTokens = remaining
 	}
+	if reset, ok := headerReset(headers, "X-RateLimit-Reset", now); ok {
+		r.lastDayReset = reset.Add(-24 * time.Hour)
+	}
 }
 
 func headerInt(headers http.Header, key string) int {
@@ -280,12 +371,45 @@ func headerInt(headers http.Header, key string) int {
 	return value
 }
 
+// headerReset parses a rate limit reset header relative to now. The value
+// may be seconds until the reset, a Unix timestamp in seconds, or an HTTP
+// date. It returns false if the header is absent, malformed, or not in the
+// future.
+func headerReset(headers http.Header, key string, now time.Time) (time.Time, bool) {
+	raw := strings.TrimSpace(headers.Get(key))
+	if raw == "" {
+		return time.Time{}, false
+	}
+
+	var reset time.Time
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		if n >= 1_000_000_000 {
+			reset = time.Unix(n, 0)
+		} else {
+			reset = now.Add(time.Duration(n) * time.Second)
+		}
+	} else if t, err := http.ParseTime(raw); err == nil {
+		reset = t
+	} else {
+		return time.Time{}, false
+	}
+
+	if !reset.After(now) {
+		return time.Time{}, false
+	}
+	return reset, true
+}
+
 // Stats returns current rate limit statistics.
 func (r *RateLimiter) Stats() RateLimitStats {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	return r.stats()
+}
 
-	now := time.Now()
+// stats implements Stats. The caller must hold r.mu.
+func (r *RateLimiter) stats() RateLimitStats {
+	now := r.clock.Now()
 
 	minuteRemaining := r.minuteTokens
 	minuteResetAt := r.lastMinuteReset.Add(time.Minute)
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -460,7 +461,9 @@ func TestRateLimiterStatsValues(t *testing.T) {
 
 // TestRateLimiterBlockedUntilReset tests that BlockedUntil expires.
 func TestRateLimiterBlockedUntilReset(t *testing.T) {
+	clock := newFakeClock()
 	rl := NewRateLimiter(10, 100)
+	rl.setClock(clock)
 
 	// Set a short block
 	rl.UpdateFromResponse(1) // 1 second
@@ -474,8 +477,8 @@ func TestRateLimiterBlockedUntilReset(t *testing.T) {
 		t.Errorf("expected ErrRateLimitExceeded, got %v", err)
 	}
 
-	// Wait for block to expire
-	time.Sleep(1100 * time.Millisecond)
+	// Let the block expire
+	clock.Advance(1100 * time.Millisecond)
 
 	// Should be allowed now
 	ok, err = rl.TryAcquire()
@@ -654,6 +657,206 @@ func TestUpdateFromHeadersUpdatesLimit(t *testing.T) {
 	}
 }
 
+// TestUpdateFromHeadersBurstExhaustedWaitsForReset tests that Wait blocks
+// until the server's reported burst reset once the remaining count hits zero.
+func TestUpdateFromHeadersBurstExhaustedWaitsForReset(t *testing.T) {
+	rl := NewRateLimiter(30, 1000)
+
+	headers := http.Header{}
+	headers.Set("X-BurstLimit-Limit", "30")
+	headers.Set("X-BurstLimit-Remaining", "0")
+	headers.Set("X-BurstLimit-Reset", "1")
+
+	rl.UpdateFromHeaders(headers)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := rl.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected Wait to block until reset, got %v", err)
+	}
+
+	start := time.Now()
+	if err := rl.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait after reset: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected Wait to return at the server reset, took %v", elapsed)
+	}
+
+	stats := rl.Stats()
+	if stats.MinuteRemaining != 29 {
+		t.Errorf("expected minute tokens refilled after reset, got %d remaining", stats.MinuteRemaining)
+	}
+}
+
+// TestUpdateFromHeadersBurstExhaustedUnixReset tests a reset header given as
+// a Unix timestamp.
+func TestUpdateFromHeadersBurstExhaustedUnixReset(t *testing.T) {
+	rl := NewRateLimiter(30, 1000)
+	reset := time.Now().Add(45 * time.Second).Truncate(time.Second)
+
+	headers := http.Header{}
+	headers.Set("X-BurstLimit-Remaining", "0")
+	headers.Set("X-BurstLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+	rl.UpdateFromHeaders(headers)
+
+	var rle *RateLimitError
+	if err := rl.Allow(); !errors.As(err, &rle) {
+		t.Fatalf("expected *RateLimitError, got %v", err)
+	}
+	if !rle.ResetAt.Equal(reset) {
+		t.Errorf("expected reset at %v, got %v", reset, rle.ResetAt)
+	}
+}
+
+// TestUpdateFromHeadersBurstExhaustedMinuteBoundary tests that without a
+// reset header the limiter waits for the next minute boundary.
+func TestUpdateFromHeadersBurstExhaustedMinuteBoundary(t *testing.T) {
+	rl := NewRateLimiter(30, 1000)
+
+	headers := http.Header{}
+	headers.Set("X-BurstLimit-Remaining", "0")
+
+	before := time.Now()
+	rl.UpdateFromHeaders(headers)
+
+	want := before.Truncate(time.Minute).Add(time.Minute)
+	if got := rl.Stats().MinuteResetAt; !got.Equal(want) && !got.Equal(want.Add(time.Minute)) {
+		t.Errorf("expected minute reset at boundary %v, got %v", want, got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if time.Until(want) > 100*time.Millisecond {
+		if err := rl.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected Wait to block until the minute boundary, got %v", err)
+		}
+	}
+}
+
+// TestUpdateFromHeadersInvalidReset tests that malformed or past reset
+// headers fall back to the minute boundary.
+func TestUpdateFromHeadersInvalidReset(t *testing.T) {
+	now := time.Now()
+	for _, value := range []string{"soon", "-5", "0"} {
+		headers := http.Header{}
+		headers.Set("X-BurstLimit-Reset", value)
+		if _, ok := headerReset(headers, "X-BurstLimit-Reset", now); ok {
+			t.Errorf("headerReset(%q) should not parse", value)
+		}
+	}
+
+	headers := http.Header{}
+	headers.Set("X-BurstLimit-Reset", now.Add(time.Hour).UTC().Format(http.TimeFormat))
+	if reset, ok := headerReset(headers, "X-BurstLimit-Reset", now); !ok || reset.Before(now) {
+		t.Errorf("expected HTTP date to parse, got %v, %v", reset, ok)
+	}
+}
+
+// TestUpdateFromHeadersResetTimestamps tests that Stats reports the
+// server-provided reset instants.
+func TestUpdateFromHeadersResetTimestamps(t *testing.T) {
+	rl := NewRateLimiter(30, 1000)
+	now := time.Now()
+	dayReset := now.Add(5 * time.Hour).Truncate(time.Second)
+
+	headers := http.Header{}
+	headers.Set("X-BurstLimit-Remaining", "12")
+	headers.Set("X-BurstLimit-Reset", "20")
+	headers.Set("X-RateLimit-Remaining", "400")
+	headers.Set("X-RateLimit-Reset", strconv.FormatInt(dayReset.Unix(), 10))
+
+	rl.UpdateFromHeaders(headers)
+
+	stats := rl.Stats()
+	if got := stats.MinuteResetAt.Sub(now); got < 19*time.Second || got > 21*time.Second {
+		t.Errorf("expected minute reset in ~20s, got %v", got)
+	}
+	if !stats.DayResetAt.Equal(dayReset) {
+		t.Errorf("expected day reset at %v, got %v", dayReset, stats.DayResetAt)
+	}
+	if stats.MinuteRemaining != 12 || stats.DayRemaining != 400 {
+		t.Errorf("expected remaining 12/400, got %d/%d", stats.MinuteRemaining, stats.DayRemaining)
+	}
+}
+
+// TestUpdateFromHeadersResetFallback tests that Stats keeps the computed
+// reset instants when no reset headers are sent.
+func TestUpdateFromHeadersResetFallback(t *testing.T) {
+	rl := NewRateLimiter(30, 1000)
+	before := rl.Stats()
+
+	headers := http.Header{}
+	headers.Set("X-BurstLimit-Remaining", "12")
+	headers.Set("X-RateLimit-Remaining", "400")
+
+	rl.UpdateFromHeaders(headers)
+
+	after := rl.Stats()
+	if !after.MinuteResetAt.Equal(before.MinuteResetAt) {
+		t.Errorf("expected minute reset %v, got %v", before.MinuteResetAt, after.MinuteResetAt)
+	}
+	if !after.DayResetAt.Equal(before.DayResetAt) {
+		t.Errorf("expected day reset %v, got %v", before.DayResetAt, after.DayResetAt)
+	}
+}
+
+// TestWaitForQuota tests that WaitForQuota returns immediately when the
+// daily budget has room and does not consume tokens.
+func TestWaitForQuota(t *testing.T) {
+	rl := NewRateLimiter(30, 100)
+
+	if err := rl.WaitForQuota(context.Background(), 100); err != nil {
+		t.Fatalf("WaitForQuota: %v", err)
+	}
+	if stats := rl.Stats(); stats.DayUsed != 0 {
+		t.Errorf("expected no tokens consumed, got %d used", stats.DayUsed)
+	}
+}
+
+// TestWaitForQuotaExceedsLimit tests that a batch larger than the daily
+// limit fails fast.
+func TestWaitForQuotaExceedsLimit(t *testing.T) {
+	rl := NewRateLimiter(30, 100)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := rl.WaitForQuota(ctx, 101)
+	if !errors.Is(err, ErrDailyLimitExceeded) {
+		t.Fatalf("expected ErrDailyLimitExceeded, got %v", err)
+	}
+	if time.Since(start) > 100*time.Millisecond {
+		t.Error("expected WaitForQuota to fail fast")
+	}
+}
+
+// TestWaitForQuotaBlocksUntilReset tests that WaitForQuota waits for the
+// daily reset when the remaining budget is too small.
+func TestWaitForQuotaBlocksUntilReset(t *testing.T) {
+	rl := NewRateLimiter(30, 100)
+
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Remaining", "10")
+	headers.Set("X-RateLimit-Reset", "1")
+	rl.UpdateFromHeaders(headers)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := rl.WaitForQuota(ctx, 50); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected WaitForQuota to block, got %v", err)
+	}
+
+	if err := rl.WaitForQuota(context.Background(), 50); err != nil {
+		t.Fatalf("WaitForQuota after reset: %v", err)
+	}
+	if stats := rl.Stats(); stats.DayRemaining != 100 {
+		t.Errorf("expected daily budget refilled, got %d remaining", stats.DayRemaining)
+	}
+}
+
 // skipIfNoAPIKeyRateLimit skips test if MOUSER_API_KEY is not set
 func skipIfNoAPIKeyRateLimit(t *testing.T) {
 	rateLimitTestInit()
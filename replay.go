@@ -0,0 +1,197 @@
+package mouser
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// recording is a recorded request and its response, stored as one JSON file.
+type recording struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	RequestBody string      `json:"request_body,omitempty"`
+	StatusCode  int         `json:"status_code"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        string      `json:"body"`
+}
+
+// RecordingTransport is an http.RoundTripper that passes requests to
+// Transport and saves each response to a JSON file in Dir, for later use
+// with ReplayTransport. The apiKey query parameter is not part of the
+// recording, and any occurrence of the key in the bodies is replaced with
+// "REDACTED", so recordings are safe to commit. Compressed responses are
+// decompressed first, so bodies are recorded as plain text and passed on
+// without a Content-Encoding. Set-Cookie headers are not recorded.
+// Repeating a request overwrites its recording.
+//
+//	client, err := mouser.NewClient(apiKey, mouser.WithHTTPClient(&http.Client{
+//	    Transport: &mouser.RecordingTransport{Dir: "testdata/mouser"},
+//	}))
+type RecordingTransport struct {
+	// Dir is the directory recordings are written to. It is created if needed.
+	Dir string
+
+	// Transport sends the requests. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("mouser: failed to read request body: %w", err)
+	}
+
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := decompressBody(resp); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	respBody, err := drainBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("mouser: failed to read response body: %w", err)
+	}
+
+	apiKey := req.URL.Query().Get("apiKey")
+	redact := func(b []byte) string {
+		if apiKey == "" {
+			return string(b)
+		}
+		return strings.ReplaceAll(string(b), apiKey, "REDACTED")
+	}
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	rec := recording{
+		Method:      req.Method,
+		URL:         recordingURL(req.URL),
+		RequestBody: redact(reqBody),
+		StatusCode:  resp.StatusCode,
+		Header:      header,
+		Body:        redact(respBody),
+	}
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("mouser: failed to encode recording: %w", err)
+	}
+	if err := os.MkdirAll(t.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("mouser: failed to create recording directory: %w", err)
+	}
+	if err := os.WriteFile(recordingPath(t.Dir, req.Method, rec.URL, reqBody), data, 0o644); err != nil {
+		return nil, fmt.Errorf("mouser: failed to write recording: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ReplayTransport is an http.RoundTripper that answers requests from the
+// recordings a RecordingTransport wrote to Dir, without network access. A
+// request matches a recording if its method, URL apart from the apiKey, and
+// body are the same, so tests may use any API key. Requests without a
+// recording fail with ErrNoRecording.
+//
+//	client, err := mouser.NewClient("test-key", mouser.WithHTTPClient(&http.Client{
+//	    Transport: &mouser.ReplayTransport{Dir: "testdata/mouser"},
+//	}))
+type ReplayTransport struct {
+	// Dir is the directory holding the recordings.
+	Dir string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("mouser: failed to read request body: %w", err)
+	}
+
+	reqURL := recordingURL(req.URL)
+	data, err := os.ReadFile(recordingPath(t.Dir, req.Method, reqURL, reqBody))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoRecording, req.Method, reqURL)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mouser: failed to read recording: %w", err)
+	}
+
+	var rec recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("mouser: invalid recording for %s %s: %w", req.Method, reqURL, err)
+	}
+	header := rec.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.StatusCode, http.StatusText(rec.StatusCode)),
+		StatusCode:    rec.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}, nil
+}
+
+// drainBody reads *body and replaces it with a fresh reader over the same
+// bytes. A nil body yields nil.
+func drainBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, err
+}
+
+// recordingURL returns u without its apiKey query parameter, with the
+// remaining parameters in canonical order.
+func recordingURL(u *url.URL) string {
+	clean := *u
+	q := clean.Query()
+	q.Del("apiKey")
+	clean.RawQuery = q.Encode()
+	return clean.String()
+}
+
+// recordingPath returns the file a request's recording is stored in, named
+// after a hash of its method, URL, and body.
+func recordingPath(dir, method, reqURL string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + reqURL + "\n"))
+	h.Write(body)
+	sum := h.Sum(nil)
+
+	name := strings.ToLower(method) + strings.ReplaceAll(urlPath(reqURL), "/", "_")
+	return filepath.Join(dir, name+"_"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// urlPath returns the path of rawURL, or "" if it cannot be parsed.
+func urlPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
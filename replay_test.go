@@ -0,0 +1,142 @@
+package mouser
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRecordAndReplay tests recording responses with one client and
+// replaying them with another that uses a different API key.
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		_, _ = w.Write([]byte(`{"Errors":[],"SearchResults":{"NumberOfResult":1,"Parts":[{"MouserPartNumber":"595-NE555P","Description":"key secret-key echoed"}]}}`))
+	}))
+	defer server.Close()
+
+	recorder, err := NewClient("secret-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithHTTPClient(&http.Client{Transport: &RecordingTransport{Dir: dir}}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer recorder.Close()
+
+	if _, err := recorder.Search.KeywordSearch(context.Background(), SearchOptions{Keyword: "NE555"}); err != nil {
+		t.Fatalf("recording search: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 recording, got %d", len(files))
+	}
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(string(data), "secret-key") {
+		t.Errorf("recording leaks the API key:\n%s", data)
+	}
+	if strings.Contains(string(data), "session=abc") {
+		t.Errorf("recording contains Set-Cookie:\n%s", data)
+	}
+	server.Close()
+
+	replayer, err := NewClient("other-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithHTTPClient(&http.Client{Transport: &ReplayTransport{Dir: dir}}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer replayer.Close()
+
+	result, err := replayer.Search.KeywordSearch(context.Background(), SearchOptions{Keyword: "NE555"})
+	if err != nil {
+		t.Fatalf("replaying search: %v", err)
+	}
+	if len(result.Parts) != 1 || result.Parts[0].MouserPartNumber != "595-NE555P" {
+		t.Errorf("unexpected replayed result: %+v", result.Parts)
+	}
+
+	_, err = replayer.Search.KeywordSearch(context.Background(), SearchOptions{Keyword: "LM317"})
+	if !errors.Is(err, ErrNoRecording) {
+		t.Errorf("expected ErrNoRecording for an unrecorded search, got %v", err)
+	}
+}
+
+// TestRecordAndReplayCompressed tests that gzip responses are recorded as
+// plain, redacted JSON and replay with WithCompression enabled.
+func TestRecordAndReplayCompressed(t *testing.T) {
+	dir := t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte(`{"Errors":[],"SearchResults":{"NumberOfResult":1,"Parts":[{"MouserPartNumber":"595-NE555P","Description":"key secret-key echoed"}]}}`))
+		_ = gz.Close()
+	}))
+	defer server.Close()
+
+	recorder, err := NewClient("secret-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithCompression(),
+		WithHTTPClient(&http.Client{Transport: &RecordingTransport{Dir: dir}}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer recorder.Close()
+
+	if _, err := recorder.Search.KeywordSearch(context.Background(), SearchOptions{Keyword: "NE555"}); err != nil {
+		t.Fatalf("recording search: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 recording, got %d", len(files))
+	}
+	data, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(data), "595-NE555P") || strings.Contains(string(data), "Content-Encoding") {
+		t.Errorf("expected a decompressed recording:\n%s", data)
+	}
+	if strings.Contains(string(data), "secret-key") {
+		t.Errorf("recording leaks the API key:\n%s", data)
+	}
+	server.Close()
+
+	replayer, err := NewClient("other-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithCompression(),
+		WithHTTPClient(&http.Client{Transport: &ReplayTransport{Dir: dir}}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer replayer.Close()
+
+	result, err := replayer.Search.KeywordSearch(context.Background(), SearchOptions{Keyword: "NE555"})
+	if err != nil {
+		t.Fatalf("replaying search: %v", err)
+	}
+	if len(result.Parts) != 1 || result.Parts[0].MouserPartNumber != "595-NE555P" {
+		t.Errorf("unexpected replayed result: %+v", result.Parts)
+	}
+}
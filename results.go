@@ -0,0 +1,127 @@
+package mouser
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Filter returns the parts for which pred returns true, in their original
+// order. The returned slice is a copy, so r.Parts is left unchanged.
+func (r *SearchResult) Filter(pred func(Part) bool) []Part {
+	var parts []Part
+	for _, part := range r.Parts {
+		if pred(part) {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// FilterByAttribute returns the parts with a ProductAttributes entry named
+// name whose value is value (e.g. "Tolerance", "1%"). Names and values are
+// compared case-insensitively and ignoring whitespace, so "± 1 %" matches
+// "±1%". Mouser repeats some attributes (such as Packaging), and a part
+// matches if any of its entries does.
+func (r *SearchResult) FilterByAttribute(name, value string) []Part {
+	name, value = normalizeAttribute(name), normalizeAttribute(value)
+	return r.Filter(func(part Part) bool {
+		for _, attr := range part.ProductAttributes {
+			if normalizeAttribute(attr.AttributeName) == name && normalizeAttribute(attr.AttributeValue) == value {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// normalizeAttribute lowercases s and removes all whitespace.
+func normalizeAttribute(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+}
+
+// UnitPrice returns the part's unit price when buying qty units: the price
+// of the largest price break at or below qty, or of the smallest break if
+// qty is below all of them. It returns false if the part has no price
+// breaks or the applicable price cannot be parsed.
+func (p Part) UnitPrice(qty int) (float64, bool) {
+	if len(p.PriceBreaks) == 0 {
+		return 0, false
+	}
+	smallest, applicable := -1, -1
+	for i, pb := range p.PriceBreaks {
+		if smallest < 0 || pb.Quantity < p.PriceBreaks[smallest].Quantity {
+			smallest = i
+		}
+		if pb.Quantity <= qty && (applicable < 0 || pb.Quantity > p.PriceBreaks[applicable].Quantity) {
+			applicable = i
+		}
+	}
+	if applicable < 0 {
+		applicable = smallest
+	}
+	return parsePrice(p.PriceBreaks[applicable].Price)
+}
+
+// SortByUnitPrice sorts Parts in place by UnitPrice(qty), cheapest first.
+// Parts without a usable price come last. Prices are compared as numbers
+// regardless of currency. The sort is stable.
+func (r *SearchResult) SortByUnitPrice(qty int) {
+	sortParts(r.Parts, func(p Part) (float64, bool) { return p.UnitPrice(qty) })
+}
+
+// SortByStockDescending sorts Parts in place by quantity in stock, largest
+// first. Parts whose stock cannot be parsed come last. The sort is stable.
+func (r *SearchResult) SortByStockDescending() {
+	sortParts(r.Parts, func(p Part) (float64, bool) {
+		n, ok := p.inStockQuantity()
+		return -float64(n), ok
+	})
+}
+
+// lifecycleRank orders lifecycle states for SortByLifecycle. Unknown ranks
+// right after Active because Mouser leaves the status empty for many
+// ordinary, orderable parts.
+var lifecycleRank = map[LifecycleStatus]float64{
+	LifecycleActive:   0,
+	LifecycleUnknown:  1,
+	LifecycleNRND:     2,
+	LifecycleEOL:      3,
+	LifecycleObsolete: 4,
+}
+
+// SortByLifecycle sorts Parts in place from the healthiest lifecycle status
+// to the least: Active, Unknown, NRND, EOL, then Obsolete. The sort is
+// stable, so parts with the same status keep their order.
+func (r *SearchResult) SortByLifecycle() {
+	sortParts(r.Parts, func(p Part) (float64, bool) { return lifecycleRank[p.Lifecycle()], true })
+}
+
+// sortParts stably sorts parts by ascending key, with parts that have no key
+// last. Keys are computed once per part.
+func sortParts(parts []Part, key func(Part) (float64, bool)) {
+	type keyed struct {
+		part Part
+		key  float64
+		ok   bool
+	}
+	sorted := make([]keyed, len(parts))
+	for i, part := range parts {
+		k, ok := key(part)
+		sorted[i] = keyed{part, k, ok}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].ok != sorted[j].ok {
+			return sorted[i].ok
+		}
+		return sorted[i].key < sorted[j].key
+	})
+	for i := range sorted {
+		parts[i] = sorted[i].part
+	}
+}
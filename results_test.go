@@ -0,0 +1,122 @@
+package mouser
+
+import (
+	"fmt"
+	"testing"
+)
+
+func attributeParts() *SearchResult {
+	return &SearchResult{Parts: []Part{
+		{MouserPartNumber: "A", ProductAttributes: []ProductAttribute{
+			{AttributeName: "Tolerance", AttributeValue: "1 %"},
+			{AttributeName: "Packaging", AttributeValue: "Reel"},
+			{AttributeName: "Packaging", AttributeValue: "Cut Tape"},
+		}},
+		{MouserPartNumber: "B", ProductAttributes: []ProductAttribute{
+			{AttributeName: "Tolerance", AttributeValue: "5%"},
+		}},
+		{MouserPartNumber: "C"},
+	}}
+}
+
+// TestFilterByAttribute tests case- and whitespace-insensitive attribute
+// matching.
+func TestFilterByAttribute(t *testing.T) {
+	result := attributeParts()
+
+	testCases := []struct {
+		name, value string
+		want        []string
+	}{
+		{"Tolerance", "1%", []string{"A"}},
+		{" tolerance ", "5 %", []string{"B"}},
+		{"PACKAGING", "cut tape", []string{"A"}},
+		{"Tolerance", "10%", nil},
+		{"Voltage", "5V", nil},
+	}
+	for _, tc := range testCases {
+		got := result.FilterByAttribute(tc.name, tc.value)
+		if len(got) != len(tc.want) {
+			t.Errorf("FilterByAttribute(%q, %q) = %d parts, want %v", tc.name, tc.value, len(got), tc.want)
+			continue
+		}
+		for i, part := range got {
+			if part.MouserPartNumber != tc.want[i] {
+				t.Errorf("FilterByAttribute(%q, %q)[%d] = %s, want %s", tc.name, tc.value, i, part.MouserPartNumber, tc.want[i])
+			}
+		}
+	}
+}
+
+// TestFilter tests predicate filtering without changing the result.
+func TestFilter(t *testing.T) {
+	result := attributeParts()
+	got := result.Filter(func(p Part) bool { return len(p.ProductAttributes) > 0 })
+	if len(got) != 2 || got[0].MouserPartNumber != "A" || got[1].MouserPartNumber != "B" {
+		t.Fatalf("unexpected filter result: %+v", got)
+	}
+	got[0].MouserPartNumber = "changed"
+	if result.Parts[0].MouserPartNumber != "A" {
+		t.Error("Filter result aliases r.Parts")
+	}
+}
+
+func partNumbers(parts []Part) []string {
+	numbers := make([]string, len(parts))
+	for i, p := range parts {
+		numbers[i] = p.MouserPartNumber
+	}
+	return numbers
+}
+
+// TestUnitPrice tests price break selection for a quantity.
+func TestUnitPrice(t *testing.T) {
+	part := Part{PriceBreaks: []PriceBreak{
+		{Quantity: 100, Price: "$0.40"},
+		{Quantity: 1, Price: "$1.00"},
+		{Quantity: 10, Price: "$0.75"},
+	}}
+	testCases := []struct {
+		qty  int
+		want float64
+	}{
+		{0, 1.00}, {1, 1.00}, {9, 1.00}, {10, 0.75}, {99, 0.75}, {1000, 0.40},
+	}
+	for _, tc := range testCases {
+		if got, ok := part.UnitPrice(tc.qty); !ok || got != tc.want {
+			t.Errorf("UnitPrice(%d) = %v, %v; want %v", tc.qty, got, ok, tc.want)
+		}
+	}
+	if _, ok := (Part{}).UnitPrice(1); ok {
+		t.Error("expected no price without price breaks")
+	}
+}
+
+// TestSortResults tests sorting by price, stock, and lifecycle.
+func TestSortResults(t *testing.T) {
+	result := &SearchResult{Parts: []Part{
+		{MouserPartNumber: "A", LifecycleStatus: "Obsolete", AvailabilityInStock: "50", PriceBreaks: []PriceBreak{{Quantity: 1, Price: "$2.00"}, {Quantity: 10, Price: "$0.50"}}},
+		{MouserPartNumber: "B", LifecycleStatus: "", Availability: "1,200 In Stock", PriceBreaks: []PriceBreak{{Quantity: 1, Price: "n/a"}}},
+		{MouserPartNumber: "C", LifecycleStatus: "New Product", AvailabilityInStock: "0", PriceBreaks: []PriceBreak{{Quantity: 1, Price: "$1.00"}}},
+		{MouserPartNumber: "D", LifecycleStatus: "Not Recommended for New Designs"},
+	}}
+
+	result.SortByUnitPrice(1)
+	if got := partNumbers(result.Parts); fmt.Sprint(got) != "[C A B D]" {
+		t.Errorf("SortByUnitPrice(1) = %v", got)
+	}
+	result.SortByUnitPrice(10)
+	if got := partNumbers(result.Parts); fmt.Sprint(got) != "[A C B D]" {
+		t.Errorf("SortByUnitPrice(10) = %v", got)
+	}
+
+	result.SortByStockDescending()
+	if got := partNumbers(result.Parts); fmt.Sprint(got) != "[B A C D]" {
+		t.Errorf("SortByStockDescending() = %v", got)
+	}
+
+	result.SortByLifecycle()
+	if got := partNumbers(result.Parts); fmt.Sprint(got) != "[C B D A]" {
+		t.Errorf("SortByLifecycle() = %v", got)
+	}
+}
@@ -1,9 +1,12 @@
 package mouser
 
 import (
+	"context"
+	"errors"
 	"math/rand"
 	"net"
 	"net/http"
+	"syscall"
 	"time"
 )
 
@@ -13,9 +16,60 @@ type RetryConfig struct {
 	InitialBackoff time.Duration // Initial backoff duration
 	MaxBackoff     time.Duration // Maximum backoff duration
 	Multiplier     float64       // Backoff multiplier
-	Jitter         float64       // Random jitter factor (0-1)
+	Jitter         float64       // Random jitter factor (0-1) for JitterPercentage
+
+	// MaxElapsedTime, if positive, bounds the time spent retrying a call:
+	// no retry is started if waiting for it would take the time since the
+	// first attempt past MaxElapsedTime, and the last error is returned
+	// instead. An attempt already in flight is not cut short; use a context
+	// deadline or WithDefaultRequestTimeout for a hard limit.
+	MaxElapsedTime time.Duration
+
+	// JitterStrategy selects how backoffs are randomized. The zero value,
+	// JitterPercentage, varies each backoff by up to ±Jitter.
+	JitterStrategy JitterStrategy
+
+	// ShouldRetry, if set, replaces the built-in decision of whether a failed
+	// attempt is retried. It receives the attempt's error and HTTP status
+	// code (0 if no response arrived). For error responses the body is in
+	// the *MouserError's Details and RawBody; API errors in a 200 response
+	// are returned without a retry. MaxRetries, the no-retry rules for
+	// submitted orders and for cart and order changes that got no response,
+	// and the quota threshold still apply. It does not
+	// change IsRetryable or MouserError.IsRetryable.
+	ShouldRetry func(err error, statusCode int) bool
 }
 
+// JitterStrategy selects how retry backoffs are randomized. Randomizing
+// keeps clients that failed together, such as several processes sharing an
+// API key, from retrying in lockstep.
+//
+// Each strategy starts from the exponential backoff InitialBackoff *
+// Multiplier^attempt, capped at MaxBackoff:
+//   - JitterPercentage (the default) varies it by up to ±Jitter. Waits stay
+//     predictable, but clients remain clustered around the same times.
+//   - JitterNone uses it unchanged, which is predictable but lets retries
+//     from many clients collide.
+//   - JitterFull waits a random time between zero and the backoff. It
+//     spreads retries the most, at the cost of sometimes retrying almost
+//     immediately.
+//   - JitterEqual waits half the backoff plus a random time up to the other
+//     half, guaranteeing some wait while still spreading retries.
+//   - JitterDecorrelated ignores Multiplier and waits a random time between
+//     InitialBackoff and three times the previous wait, capped at
+//     MaxBackoff. This is the AWS "decorrelated jitter" scheme; it spreads
+//     retries well under contention while backing off about as fast as
+//     exponential backoff, but individual waits vary widely.
+type JitterStrategy int
+
+const (
+	JitterPercentage JitterStrategy = iota
+	JitterNone
+	JitterFull
+	JitterEqual
+	JitterDecorrelated
+)
+
 // DefaultRetryConfig returns the default retry configuration.
 func DefaultRetryConfig() RetryConfig {
 	return RetryConfig{
@@ -34,6 +88,15 @@ func NoRetry() RetryConfig {
 	}
 }
 
+// retryable reports whether a failed attempt should be retried, using
+// ShouldRetry if it is set.
+func (c RetryConfig) retryable(err error, statusCode int) bool {
+	if c.ShouldRetry != nil {
+		return c.ShouldRetry(err, statusCode)
+	}
+	return shouldRetry(err, statusCode)
+}
+
 // shouldRetry determines if a request should be retried based on the error and status code.
 func shouldRetry(err error, statusCode int) bool {
 	if err != nil {
@@ -61,31 +124,98 @@ func shouldRetry(err error, statusCode int) bool {
 	return false
 }
 
-// isTemporaryNetworkError checks if the error is a temporary network error.
+// IsRetryable reports whether a request that failed with err may succeed if
+// sent again, by the same rules the client's own retries follow: a
+// *MouserError reports its IsRetryable field (set for 429, 500, 502, 503,
+// and 504 responses), and network timeouts, connection resets, and
+// temporary network errors other than DNS "no such host" failures are
+// retryable. A local minute rate limit error is retryable once the
+// limit resets; the daily limit, cancelled contexts, and all other errors
+// are not. Use RetryAfter to learn how long to wait.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var mouserErr *MouserError
+	if errors.As(err, &mouserErr) {
+		return mouserErr.IsRetryable
+	}
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) {
+		return !errors.Is(rateErr, ErrDailyLimitExceeded)
+	}
+	return shouldRetry(err, 0)
+}
+
+// RetryAfter returns how long to wait before retrying a request that failed
+// with err: the server's Retry-After for a *MouserError, or the time until
+// the limit resets for a *RateLimitError. It returns false if err carries
+// no such hint.
+func RetryAfter(err error) (time.Duration, bool) {
+	var mouserErr *MouserError
+	if errors.As(err, &mouserErr) && mouserErr.RetryAfter > 0 {
+		return time.Duration(mouserErr.RetryAfter) * time.Second, true
+	}
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) && !rateErr.ResetAt.IsZero() {
+		return max(rateErr.ResetAt.Sub(clockOrReal(rateErr.clock).Now()), 0), true
+	}
+	return 0, false
+}
+
+// isTemporaryNetworkError checks if the error is, or wraps, a temporary
+// network error. Connection resets count as temporary. A DNS lookup that
+// found no such host is permanent, even if the resolver marks it temporary,
+// so it fails fast instead of being retried.
 func isTemporaryNetworkError(err error) bool {
-	if netErr, ok := err.(net.Error); ok {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
 		//nolint:staticcheck // Temporary() is deprecated but still useful for some errors
 		return netErr.Temporary()
 	}
 	return false
 }
 
-// isTimeoutError checks if the error is a timeout error.
+// isTimeoutError checks if the error is, or wraps, a timeout error.
 func isTimeoutError(err error) bool {
-	if netErr, ok := err.(net.Error); ok {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
 		return netErr.Timeout()
 	}
 	return false
 }
 
 // calculateBackoff calculates the backoff duration for a retry attempt.
-func (c RetryConfig) calculateBackoff(attempt int) time.Duration {
+// prev is the previous backoff, or zero before the first retry; only
+// JitterDecorrelated uses it.
+func (c RetryConfig) calculateBackoff(attempt int, prev time.Duration) time.Duration {
 	backoff := float64(c.InitialBackoff) * pow(c.Multiplier, float64(attempt))
 
 	// Apply jitter
-	if c.Jitter > 0 {
-		jitter := backoff * c.Jitter * (rand.Float64()*2 - 1)
-		backoff += jitter
+	switch c.JitterStrategy {
+	case JitterNone:
+	case JitterFull:
+		backoff = rand.Float64() * backoff
+	case JitterEqual:
+		backoff = backoff/2 + rand.Float64()*backoff/2
+	case JitterDecorrelated:
+		base := float64(c.InitialBackoff)
+		upper := max(float64(prev)*3, base)
+		backoff = base + rand.Float64()*(upper-base)
+	default:
+		if c.Jitter > 0 {
+			jitter := backoff * c.Jitter * (rand.Float64()*2 - 1)
+			backoff += jitter
+		}
 	}
 
 	// Cap at max backoff
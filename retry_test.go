@@ -1,6 +1,18 @@
 package mouser
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -41,9 +53,9 @@ func TestCalculateBackoff(t *testing.T) {
 		Jitter:         0.0, // Disable jitter for predictability
 	}
 
-	backoff1 := config.calculateBackoff(0)
-	backoff2 := config.calculateBackoff(1)
-	backoff3 := config.calculateBackoff(2)
+	backoff1 := config.calculateBackoff(0, 0)
+	backoff2 := config.calculateBackoff(1, 0)
+	backoff3 := config.calculateBackoff(2, 0)
 
 	if backoff1 != 100*time.Millisecond {
 		t.Errorf("expected first backoff 100ms, got %v", backoff1)
@@ -67,8 +79,8 @@ func TestCalculateBackoffWithJitter(t *testing.T) {
 		Jitter:         0.1,
 	}
 
-	backoff1 := config.calculateBackoff(0)
-	backoff2 := config.calculateBackoff(0)
+	backoff1 := config.calculateBackoff(0, 0)
+	backoff2 := config.calculateBackoff(0, 0)
 
 	// Both should be around 100ms but might differ due to jitter
 	if backoff1 < 50*time.Millisecond || backoff1 > 150*time.Millisecond {
@@ -80,6 +92,40 @@ func TestCalculateBackoffWithJitter(t *testing.T) {
 	}
 }
 
+// TestCalculateBackoffJitterStrategies tests the bounds of each jitter
+// strategy.
+func TestCalculateBackoffJitterStrategies(t *testing.T) {
+	config := RetryConfig{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2.0,
+		Jitter:         0.5,
+	}
+
+	testCases := []struct {
+		strategy JitterStrategy
+		attempt  int
+		prev     time.Duration
+		min, max time.Duration
+	}{
+		{JitterNone, 1, 0, 200 * time.Millisecond, 200 * time.Millisecond},
+		{JitterFull, 1, 0, 0, 200 * time.Millisecond},
+		{JitterEqual, 1, 0, 100 * time.Millisecond, 200 * time.Millisecond},
+		{JitterDecorrelated, 0, 0, 100 * time.Millisecond, 100 * time.Millisecond},
+		{JitterDecorrelated, 3, 200 * time.Millisecond, 100 * time.Millisecond, 600 * time.Millisecond},
+		{JitterDecorrelated, 5, 900 * time.Millisecond, 100 * time.Millisecond, time.Second},
+	}
+	for _, tc := range testCases {
+		config.JitterStrategy = tc.strategy
+		for i := 0; i < 100; i++ {
+			got := config.calculateBackoff(tc.attempt, tc.prev)
+			if got < tc.min || got > tc.max {
+				t.Fatalf("strategy %d attempt %d prev %v: backoff %v outside [%v, %v]", tc.strategy, tc.attempt, tc.prev, got, tc.min, tc.max)
+			}
+		}
+	}
+}
+
 // TestCalculateBackoffMaxCap tests backoff max cap.
 func TestCalculateBackoffMaxCap(t *testing.T) {
 	config := RetryConfig{
@@ -89,10 +135,10 @@ func TestCalculateBackoffMaxCap(t *testing.T) {
 		Jitter:         0.0,
 	}
 
-	backoff1 := config.calculateBackoff(0)
-	backoff2 := config.calculateBackoff(1)
-	backoff3 := config.calculateBackoff(2)
-	backoff4 := config.calculateBackoff(3)
+	backoff1 := config.calculateBackoff(0, 0)
+	backoff2 := config.calculateBackoff(1, 0)
+	backoff3 := config.calculateBackoff(2, 0)
+	backoff4 := config.calculateBackoff(3, 0)
 
 	if backoff1 != 1*time.Second {
 		t.Errorf("expected 1s, got %v", backoff1)
@@ -199,6 +245,50 @@ func TestShouldRetryNilError(t *testing.T) {
 	}
 }
 
+// TestIsRetryable tests the exported retry classification.
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"503", &MouserError{StatusCode: 503, IsRetryable: true}, true},
+		{"400", &MouserError{StatusCode: 400}, false},
+		{"wrapped 429", fmt.Errorf("search: %w", &MouserError{StatusCode: 429, IsRetryable: true}), true},
+		{"wrapped timeout", fmt.Errorf("mouser: request failed: %w", &timeoutError{}), true},
+		{"minute limit", &RateLimitError{Type: "minute"}, true},
+		{"daily limit", &RateLimitError{Type: "day"}, false},
+		{"canceled", context.Canceled, false},
+		{"other", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: IsRetryable() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestRetryAfter tests extracting a wait hint from errors.
+func TestRetryAfter(t *testing.T) {
+	if d, ok := RetryAfter(fmt.Errorf("wrapped: %w", &MouserError{StatusCode: 429, RetryAfter: 30})); !ok || d != 30*time.Second {
+		t.Errorf("RetryAfter(MouserError) = %v, %v; want 30s, true", d, ok)
+	}
+
+	resetAt := time.Now().Add(time.Minute)
+	if d, ok := RetryAfter(&RateLimitError{Type: "minute", ResetAt: resetAt}); !ok || d <= 0 || d > time.Minute {
+		t.Errorf("RetryAfter(RateLimitError) = %v, %v; want up to 1m, true", d, ok)
+	}
+
+	if _, ok := RetryAfter(&MouserError{StatusCode: 500}); ok {
+		t.Error("expected no hint without Retry-After")
+	}
+	if _, ok := RetryAfter(errors.New("boom")); ok {
+		t.Error("expected no hint for a plain error")
+	}
+}
+
 // Helper timeout error for testing
 type timeoutError struct{}
 
@@ -258,6 +348,77 @@ func TestIsTemporaryNetworkError(t *testing.T) {
 	}
 }
 
+// TestDNSNotFoundNotRetried tests that a host that does not exist is not
+// retried, while connection resets and DNS timeouts still are.
+func TestDNSNotFoundNotRetried(t *testing.T) {
+	wrap := func(err error) error {
+		return &url.Error{Op: "Post", URL: "https://api.mouser.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: err}}
+	}
+
+	notFound := wrap(&net.DNSError{Err: "no such host", Name: "api.mouser.invalid", IsNotFound: true, IsTemporary: true})
+	if isTemporaryNetworkError(notFound) || shouldRetry(notFound, 0) || IsRetryable(notFound) {
+		t.Error("expected a DNS not found error not to be retried")
+	}
+
+	dnsTimeout := wrap(&net.DNSError{Err: "i/o timeout", Name: "api.mouser.com", IsTimeout: true})
+	if !shouldRetry(dnsTimeout, 0) {
+		t.Error("expected a DNS timeout to be retried")
+	}
+
+	reset := wrap(os.NewSyscallError("read", syscall.ECONNRESET))
+	if !isTemporaryNetworkError(reset) || !shouldRetry(reset, 0) {
+		t.Error("expected a connection reset to be retried")
+	}
+}
+
+// TestTransportErrorNotRetriedForCartChanges tests that a cart insert that
+// timed out is not resent, while a search is retried.
+func TestTransportErrorNotRetriedForCartChanges(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		mu.Unlock()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithHTTPClient(&http.Client{Timeout: 20 * time.Millisecond}),
+		WithoutCache(),
+		WithRetryConfig(RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	body := CartItemRequestBody{CartItems: []CartItemRequest{{MouserPartNumber: "595-NE555P", Quantity: 10}}}
+	if _, err := client.Cart.InsertItems(ctx, body, "", ""); err == nil {
+		t.Fatal("expected a timeout from the cart insert")
+	}
+	if _, err := client.Search.KeywordSearch(ctx, SearchOptions{Keyword: "NE555"}); err == nil {
+		t.Fatal("expected a timeout from the search")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if n := calls["/cart/items/insert"]; n != 1 {
+		t.Errorf("expected the cart insert to be sent once, got %d", n)
+	}
+	if n := calls["/search/keyword"]; n != 3 {
+		t.Errorf("expected the search to be retried twice, got %d attempts", n)
+	}
+}
+
 // TestRetryConfigDefaults tests that custom config can override defaults.
 func TestRetryConfigDefaults(t *testing.T) {
 	customConfig := RetryConfig{
@@ -287,7 +448,7 @@ func TestBackoffProgression(t *testing.T) {
 
 	backoffs := make([]time.Duration, 0)
 	for i := 0; i < 10; i++ {
-		backoff := config.calculateBackoff(i)
+		backoff := config.calculateBackoff(i, 0)
 		backoffs = append(backoffs, backoff)
 	}
 
@@ -305,3 +466,248 @@ func TestBackoffProgression(t *testing.T) {
 		}
 	}
 }
+
+// TestRetryBackoffHonorsRetryAfter tests that the server's Retry-After wins when longer.
+func TestRetryBackoffHonorsRetryAfter(t *testing.T) {
+	if got := retryBackoff(500*time.Millisecond, 0); got != 500*time.Millisecond {
+		t.Errorf("expected calculated backoff without Retry-After, got %v", got)
+	}
+	if got := retryBackoff(500*time.Millisecond, 2); got != 2*time.Second {
+		t.Errorf("expected Retry-After backoff, got %v", got)
+	}
+	if got := retryBackoff(5*time.Second, 2); got != 5*time.Second {
+		t.Errorf("expected longer calculated backoff, got %v", got)
+	}
+	if got := retryBackoff(time.Second, 3600); got != 300*time.Second {
+		t.Errorf("expected Retry-After capped at 5 minutes, got %v", got)
+	}
+}
+
+// TestRetryServiceUnavailableRetryAfter tests that a 503 with Retry-After
+// delays the next attempt by at least the server's guidance.
+func TestRetryServiceUnavailableRetryAfter(t *testing.T) {
+	var calls int
+	var times []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		times = append(times, time.Now())
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutCache(),
+		WithRetryConfig(RetryConfig{
+			MaxRetries:     2,
+			InitialBackoff: 10 * time.Millisecond,
+			MaxBackoff:     50 * time.Millisecond,
+			Multiplier:     2.0,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	var resp map[string]string
+	if err := client.doRequest(context.Background(), "GET", "/test", nil, &resp); err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+	if gap := times[1].Sub(times[0]); gap < time.Second {
+		t.Errorf("expected retry to wait at least 1s for Retry-After, waited %v", gap)
+	}
+}
+
+// TestRetryConfigShouldRetry tests that a custom predicate replaces the
+// built-in retry decision.
+func TestRetryConfigShouldRetry(t *testing.T) {
+	var calls atomic.Int32
+	status := http.StatusBadGateway
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"Errors":[{"Id":1,"Code":"Busy","Message":"try again"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutCache(),
+		WithRetryConfig(RetryConfig{
+			MaxRetries:     2,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+			Multiplier:     1,
+			ShouldRetry: func(err error, statusCode int) bool {
+				var mErr *MouserError
+				if errors.As(err, &mErr) && strings.Contains(mErr.Details, `"Busy"`) {
+					return statusCode == http.StatusBadRequest
+				}
+				return false
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	var resp map[string]string
+	if err := client.doRequest(context.Background(), "GET", "/test", nil, &resp); err == nil {
+		t.Fatal("expected an error")
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected the 502 not to be retried, got %d calls", n)
+	}
+
+	calls.Store(0)
+	status = http.StatusBadRequest
+	if err := client.doRequest(context.Background(), "GET", "/test", nil, &resp); err == nil {
+		t.Fatal("expected an error")
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("expected the 400 to be retried twice, got %d calls", n)
+	}
+}
+
+// TestRetryMaxElapsedTime tests that retries stop once the next wait would
+// exceed MaxElapsedTime, returning the last error.
+func TestRetryMaxElapsedTime(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutCache(),
+		WithRetryConfig(RetryConfig{
+			MaxRetries:     10,
+			InitialBackoff: 40 * time.Millisecond,
+			MaxBackoff:     40 * time.Millisecond,
+			Multiplier:     1,
+			MaxElapsedTime: 100 * time.Millisecond,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	start := time.Now()
+	var resp map[string]string
+	err = client.doRequest(context.Background(), "GET", "/test", nil, &resp)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, ErrServerError) {
+		t.Errorf("expected the last server error, got %v", err)
+	}
+	if n := calls.Load(); n < 2 || n > 3 {
+		t.Errorf("expected 2-3 attempts within 100ms, got %d", n)
+	}
+	if elapsed > 100*time.Millisecond+50*time.Millisecond {
+		t.Errorf("expected retries to stop near 100ms, took %v", elapsed)
+	}
+}
+
+// TestRetryRespectsRequestTimeout tests that the default request timeout bounds
+// all attempts together rather than each attempt individually.
+func TestRetryRespectsRequestTimeout(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(30 * time.Millisecond)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutCache(),
+		WithRetryConfig(RetryConfig{
+			MaxRetries:     20,
+			InitialBackoff: 20 * time.Millisecond,
+			MaxBackoff:     20 * time.Millisecond,
+			Multiplier:     1.0,
+		}),
+		WithDefaultRequestTimeout(150*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	start := time.Now()
+	err = client.doRequest(context.Background(), "GET", "/search/keyword", nil, nil)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if elapsed > time.Second {
+		t.Errorf("expected retries to stop near the 150ms deadline, took %v", elapsed)
+	}
+	if n := atomic.LoadInt32(&calls); n >= 21 {
+		t.Errorf("expected the deadline to cut retries short, got %d calls", n)
+	}
+}
+
+// TestQuotaAwareRetry tests that retries stop once the daily quota falls
+// below the threshold, and continue above it.
+func TestQuotaAwareRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		remaining string
+		wantCalls int32
+	}{
+		{"below threshold", "5", 1},
+		{"above threshold", "500", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.Header().Set("X-RateLimit-Remaining", tt.remaining)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test-key",
+				WithBaseURL(server.URL),
+				WithoutCache(),
+				WithRetryConfig(RetryConfig{
+					MaxRetries:     3,
+					InitialBackoff: time.Millisecond,
+					MaxBackoff:     time.Millisecond,
+					Multiplier:     1.0,
+				}),
+				WithQuotaAwareRetry(10),
+			)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			defer client.Close()
+
+			if err := client.doRequest(context.Background(), "GET", "/test", nil, nil); err == nil {
+				t.Fatal("expected error")
+			}
+			if n := atomic.LoadInt32(&calls); n != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package mouser
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// statusErrorWindow is how far back ClientStatus.RecentErrors looks.
+const statusErrorWindow = 5 * time.Minute
+
+// ClientStatus is a snapshot of a Client's health, shaped for JSON health
+// endpoints. StatusJSON encodes it as:
+//
+//	{
+//	  "rate_limit": {
+//	    "minute_limit": 30, "minute_used": 4, "minute_remaining": 26,
+//	    "minute_reset_at": "2025-01-02T15:04:05Z",
+//	    "day_limit": 1000, "day_used": 120, "day_remaining": 880,
+//	    "day_reset_at": "2025-01-03T09:00:00Z",
+//	    "blocked_until": "2025-01-02T15:05:00Z"
+//	  },
+//	  "cache": {"enabled": true, "entries": 12, "hits": 40, "misses": 9},
+//	  "circuit": "closed",
+//	  "recent_errors": 1,
+//	  "recent_error_window_seconds": 300
+//	}
+//
+// blocked_until is omitted unless the server has asked the client to back
+// off, and cache.entries is omitted for custom Cache implementations.
+type ClientStatus struct {
+	RateLimit RateLimitStatus `json:"rate_limit"`
+	Cache     CacheStatus     `json:"cache"`
+
+	// Circuit is "closed", "open", or "half-open", or "disabled" if the
+	// client has no circuit breaker.
+	Circuit string `json:"circuit"`
+
+	// RecentErrors counts requests that failed, after retries, within the
+	// last RecentErrorWindowSeconds. Requests canceled by the caller are not
+	// counted.
+	RecentErrors             int `json:"recent_errors"`
+	RecentErrorWindowSeconds int `json:"recent_error_window_seconds"`
+}
+
+// RateLimitStatus is the JSON form of RateLimitStats.
+type RateLimitStatus struct {
+	MinuteLimit     int        `json:"minute_limit"`
+	MinuteUsed      int        `json:"minute_used"`
+	MinuteRemaining int        `json:"minute_remaining"`
+	MinuteResetAt   time.Time  `json:"minute_reset_at"`
+	DayLimit        int        `json:"day_limit"`
+	DayUsed         int        `json:"day_used"`
+	DayRemaining    int        `json:"day_remaining"`
+	DayResetAt      time.Time  `json:"day_reset_at"`
+	BlockedUntil    *time.Time `json:"blocked_until,omitempty"`
+}
+
+// CacheStatus reports response cache usage. Hits and Misses count lookups
+// since the client was created.
+type CacheStatus struct {
+	Enabled bool  `json:"enabled"`
+	Entries *int  `json:"entries,omitempty"`
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+}
+
+// Status returns a snapshot of the client's rate limit, cache, and circuit
+// breaker state along with its recent error count.
+func (c *Client) Status() ClientStatus {
+	stats := c.rateLimiter.Stats()
+	status := ClientStatus{
+		RateLimit: RateLimitStatus{
+			MinuteLimit:     stats.MinuteLimit,
+			MinuteUsed:      stats.MinuteUsed,
+			MinuteRemaining: stats.MinuteRemaining,
+			MinuteResetAt:   stats.MinuteResetAt,
+			DayLimit:        stats.DayLimit,
+			DayUsed:         stats.DayUsed,
+			DayRemaining:    stats.DayRemaining,
+			DayResetAt:      stats.DayResetAt,
+		},
+		Cache: CacheStatus{
+			Enabled: c.cache != nil && c.cacheConfig.Enabled,
+			Hits:    c.cacheHits.Load(),
+			Misses:  c.cacheMisses.Load(),
+		},
+		Circuit:                  "disabled",
+		RecentErrors:             c.recentErrors.count(),
+		RecentErrorWindowSeconds: int(statusErrorWindow / time.Second),
+	}
+
+	if stats.BlockedUntil.After(c.rateLimiter.now()) {
+		blockedUntil := stats.BlockedUntil
+		status.RateLimit.BlockedUntil = &blockedUntil
+	}
+	if mc, ok := c.cache.(*MemoryCache); ok && status.Cache.Enabled {
+		entries := mc.Size()
+		status.Cache.Entries = &entries
+	}
+	if c.circuitBreaker != nil {
+		status.Circuit = c.circuitBreaker.currentState().String()
+	}
+
+	return status
+}
+
+// StatusJSON returns Status encoded as JSON, ready to serve from a health
+// endpoint. See ClientStatus for the shape.
+func (c *Client) StatusJSON() ([]byte, error) {
+	return json.Marshal(c.Status())
+}
+
+// errorTracker counts events within a sliding time window.
+type errorTracker struct {
+	mu     sync.Mutex
+	window time.Duration
+	times  []time.Time
+}
+
+// newErrorTracker creates an errorTracker with the given window.
+func newErrorTracker(window time.Duration) *errorTracker {
+	return &errorTracker{window: window}
+}
+
+// record notes an error at the current time.
+func (t *errorTracker) record() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.prune(now)
+	t.times = append(t.times, now)
+}
+
+// count returns the number of errors within the window.
+func (t *errorTracker) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune(time.Now())
+	return len(t.times)
+}
+
+// prune drops errors older than the window. t.mu must be held.
+func (t *errorTracker) prune(now time.Time) {
+	cutoff := now.Add(-t.window)
+	i := 0
+	for i < len(t.times) && !t.times[i].After(cutoff) {
+		i++
+	}
+	t.times = t.times[i:]
+}
+
+// Ping checks that the Mouser API is reachable and accepts the API key,
+// for readiness probes. It fetches the currency list, one of the smallest
+// authenticated responses, bypassing the cache and without retries. Mouser
+// has no unmetered health endpoint, so each Ping counts as one request
+// against the rate limits. It returns nil on success, an error wrapping
+// ErrUnauthorized for a rejected key, and the transport or rate limit
+// error otherwise.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.Order.Currencies(withoutRetries(withoutCacheRead(ctx)), "")
+	return err
+}
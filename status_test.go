@@ -0,0 +1,167 @@
+package mouser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestStatusJSONMock tests that StatusJSON reports cache, circuit, and error activity.
+func TestStatusJSONMock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/cart" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"SearchResults": {"NumberOfResult": 1, "Parts": [{"MouserPartNumber": "595-NE555P"}]}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithRateLimiter(NewRateLimiter(10000, 100000)),
+		WithCircuitBreaker(5, time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := client.Search.PartDetails(ctx, "595-NE555P"); err != nil {
+			t.Fatalf("PartDetails: %v", err)
+		}
+	}
+	if _, err := client.Cart.Get(ctx, "some-cart", "", ""); err == nil {
+		t.Fatal("expected cart error")
+	}
+
+	data, err := client.StatusJSON()
+	if err != nil {
+		t.Fatalf("StatusJSON: %v", err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("StatusJSON returned invalid JSON: %v\n%s", err, data)
+	}
+	for _, field := range []string{"rate_limit", "cache", "circuit", "recent_errors", "recent_error_window_seconds"} {
+		if _, ok := raw[field]; !ok {
+			t.Errorf("missing field %q in %s", field, data)
+		}
+	}
+
+	var status ClientStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		t.Fatalf("failed to decode status: %v", err)
+	}
+	if status.RateLimit.MinuteLimit != 10000 || status.RateLimit.MinuteUsed != 2 {
+		t.Errorf("unexpected rate limit status: %+v", status.RateLimit)
+	}
+	if status.RateLimit.BlockedUntil != nil {
+		t.Errorf("expected no blocked_until, got %v", status.RateLimit.BlockedUntil)
+	}
+	// The first PartDetails misses both the details and search caches and
+	// fills both; the second hits the details cache.
+	if !status.Cache.Enabled || status.Cache.Hits != 1 || status.Cache.Misses != 2 {
+		t.Errorf("unexpected cache status: %+v", status.Cache)
+	}
+	if status.Cache.Entries == nil || *status.Cache.Entries != 2 {
+		t.Errorf("expected 2 cache entries, got %v", status.Cache.Entries)
+	}
+	if status.Circuit != "closed" {
+		t.Errorf("expected closed circuit, got %q", status.Circuit)
+	}
+	if status.RecentErrors != 1 || status.RecentErrorWindowSeconds != 300 {
+		t.Errorf("expected 1 recent error in 300s, got %d in %ds", status.RecentErrors, status.RecentErrorWindowSeconds)
+	}
+}
+
+// TestStatusDefaults tests the status of an unused client without a circuit breaker.
+func TestStatusDefaults(t *testing.T) {
+	client, err := NewClient("test-key", WithoutCache())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	status := client.Status()
+	if status.Circuit != "disabled" {
+		t.Errorf("expected disabled circuit, got %q", status.Circuit)
+	}
+	if status.Cache.Enabled || status.Cache.Entries != nil {
+		t.Errorf("expected disabled cache without entries, got %+v", status.Cache)
+	}
+	if status.RecentErrors != 0 {
+		t.Errorf("expected no recent errors, got %d", status.RecentErrors)
+	}
+}
+
+// TestErrorTrackerWindow tests that errors age out of the window.
+func TestErrorTrackerWindow(t *testing.T) {
+	tracker := newErrorTracker(50 * time.Millisecond)
+	tracker.record()
+	tracker.record()
+	if n := tracker.count(); n != 2 {
+		t.Fatalf("expected 2 errors, got %d", n)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	tracker.record()
+	if n := tracker.count(); n != 1 {
+		t.Errorf("expected old errors to age out, got %d", n)
+	}
+}
+
+// TestPingMock tests that Ping reaches the API on every call and maps an
+// invalid key to ErrUnauthorized.
+func TestPingMock(t *testing.T) {
+	var calls int
+	status := http.StatusOK
+	client := newTestClientCached(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/order/currencies" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			_, _ = w.Write([]byte(`{"Currencies": [{"CurrencyCode": "USD"}]}`))
+		}
+	}))
+	ctx := context.Background()
+
+	if err := client.Ping(ctx); err != nil {
+		t.Fatalf("expected Ping to succeed, got %v", err)
+	}
+	if err := client.Ping(ctx); err != nil || calls != 2 {
+		t.Fatalf("expected a second uncached request, got %d calls, err %v", calls, err)
+	}
+
+	status = http.StatusUnauthorized
+	if err := client.Ping(ctx); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+// TestPingUnreachable tests that Ping reports transport errors.
+func TestPingUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(server.URL), WithoutCache())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Ping(context.Background()); err == nil || errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected a transport error, got %v", err)
+	}
+}
@@ -3,10 +3,16 @@ package mouser
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // newTestClient creates a *Client wired to an httptest.Server running the given handler.
@@ -295,6 +301,101 @@ func TestSearchAllByManufacturerMock(t *testing.T) {
 	}
 }
 
+// TestSearchAllCachedPagesMock tests that each page is cached under its own
+// offset, so repeating an iteration within the TTL makes no requests.
+func TestSearchAllCachedPagesMock(t *testing.T) {
+	const total = 120
+	page := func(start int) []Part {
+		var parts []Part
+		for i := start; i < min(start+MaxRecords, total); i++ {
+			parts = append(parts, Part{MouserPartNumber: fmt.Sprintf("P%03d", i)})
+		}
+		return parts
+	}
+
+	requests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var start int
+		if r.URL.Path == "/search/keywordandmanufacturer" {
+			var req keywordAndManufacturerSearchRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			start = (req.SearchByKeywordMfrNameRequest.PageNumber - 1) * req.SearchByKeywordMfrNameRequest.Records
+		} else {
+			var req keywordSearchRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			start = req.SearchByKeywordRequest.StartingRecord
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(searchResponse{SearchResults: SearchResult{NumberOfResult: total, Parts: page(start)}})
+	})
+	client := newTestClientCached(t, handler)
+	ctx := context.Background()
+
+	iterations := map[string]func(func(Part) bool) error{
+		"All": func(cb func(Part) bool) error {
+			return client.Search.All(ctx, SearchOptions{Keyword: "timer"}, cb)
+		},
+		"AllByManufacturer": func(cb func(Part) bool) error {
+			return client.Search.AllByManufacturer(ctx, KeywordAndManufacturerSearchOptions{Keyword: "timer", ManufacturerName: "Texas Instruments"}, cb)
+		},
+	}
+	for name, iterate := range iterations {
+		requests = 0
+		for run := 1; run <= 2; run++ {
+			seen := make(map[string]bool)
+			if err := iterate(func(p Part) bool { seen[p.MouserPartNumber] = true; return true }); err != nil {
+				t.Fatalf("%s run %d: %v", name, run, err)
+			}
+			if len(seen) != total {
+				t.Errorf("%s run %d: expected %d distinct parts, got %d", name, run, total, len(seen))
+			}
+		}
+		if requests != 3 {
+			t.Errorf("%s: expected 3 page requests across both runs, got %d", name, requests)
+		}
+	}
+}
+
+// TestSearchAllBudgetMock tests that a search budget stops paging with
+// ErrSearchBudgetExceeded after delivering the parts fetched so far.
+func TestSearchAllBudgetMock(t *testing.T) {
+	var page atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if page.Add(1) > 1 {
+			// Later pages are too slow for the budget. Draining the body lets
+			// the server notice the client hanging up.
+			_, _ = io.Copy(io.Discard, r.Body)
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+			return
+		}
+		parts := make([]Part, MaxRecords)
+		for i := range parts {
+			parts[i].MouserPartNumber = fmt.Sprintf("P%d", i)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(searchResponse{SearchResults: SearchResult{NumberOfResult: 500, Parts: parts}})
+	})
+
+	client := newTestClient(t, handler)
+	ctx := ContextWithSearchBudget(context.Background(), 100*time.Millisecond)
+
+	collected := 0
+	err := client.Search.All(ctx, SearchOptions{Keyword: "resistor"}, func(Part) bool {
+		collected++
+		return true
+	})
+	if !errors.Is(err, ErrSearchBudgetExceeded) {
+		t.Fatalf("expected ErrSearchBudgetExceeded, got %v", err)
+	}
+	if collected != MaxRecords {
+		t.Errorf("expected the first page of %d parts, got %d", MaxRecords, collected)
+	}
+}
+
 // TestSearchAllByManufacturerEarlyStopMock tests callback returning false.
 func TestSearchAllByManufacturerEarlyStopMock(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -409,6 +510,51 @@ func TestKeywordSearchMock(t *testing.T) {
 	}
 }
 
+// TestKeywordSearchWithMetaMock tests the cache-miss and cache-hit metadata.
+func TestKeywordSearchWithMetaMock(t *testing.T) {
+	var requests int
+	client := newTestClientCached(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Errors": [], "SearchResults": {"NumberOfResult": 1, "Parts": [{"MouserPartNumber": "595-NE555P"}]}}`))
+	}))
+
+	opts := SearchOptions{Keyword: "timer"}
+
+	before := time.Now()
+	result, meta, err := client.Search.KeywordSearchWithMeta(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Parts) != 1 {
+		t.Fatalf("expected 1 part, got %d", len(result.Parts))
+	}
+	if meta.FromCache {
+		t.Error("expected first search not to come from the cache")
+	}
+	if meta.FetchedAt.Before(before) || meta.FetchedAt.After(time.Now()) {
+		t.Errorf("expected FetchedAt during the call, got %v", meta.FetchedAt)
+	}
+	fetchedAt := meta.FetchedAt
+
+	time.Sleep(20 * time.Millisecond)
+
+	result, meta, err = client.Search.KeywordSearchWithMeta(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 1 || len(result.Parts) != 1 {
+		t.Fatalf("expected a cached result without a second request, got %d requests", requests)
+	}
+	if !meta.FromCache {
+		t.Error("expected second search to come from the cache")
+	}
+	// FetchedAt is derived from the entry's age, so allow a little slack.
+	if diff := meta.FetchedAt.Sub(fetchedAt); diff < -5*time.Millisecond || diff > 5*time.Millisecond {
+		t.Errorf("expected cached FetchedAt near %v, got %v", fetchedAt, meta.FetchedAt)
+	}
+}
+
 // TestPartNumberSearchMock tests PartNumberSearch (verifies bug fix from Phase 1).
 func TestPartNumberSearchMock(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -460,6 +606,57 @@ func TestPartNumberSearchMock(t *testing.T) {
 	}
 }
 
+// TestKeywordSearchWithMetaRateLimitMock tests the per-call request count
+// and rate limit snapshot, including retried attempts.
+func TestKeywordSearchWithMetaRateLimitMock(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.Header().Set("X-BurstLimit-Limit", "30")
+		w.Header().Set("X-BurstLimit-Remaining", fmt.Sprint(30-n))
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(1000-n))
+		if n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Errors": [], "SearchResults": {"NumberOfResult": 1, "Parts": [{"MouserPartNumber": "595-NE555P"}]}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithRetryConfig(RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	opts := SearchOptions{Keyword: "timer"}
+	_, meta, err := client.Search.KeywordSearchWithMeta(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.Requests != 2 {
+		t.Errorf("expected 2 requests including the retry, got %d", meta.Requests)
+	}
+	if meta.RateLimit.MinuteRemaining != 28 || meta.RateLimit.DayRemaining != 998 {
+		t.Errorf("expected the snapshot from the last response (28/998), got %d/%d",
+			meta.RateLimit.MinuteRemaining, meta.RateLimit.DayRemaining)
+	}
+
+	_, meta, err = client.Search.KeywordSearchWithMeta(context.Background(), opts)
+	if err != nil || !meta.FromCache {
+		t.Fatalf("expected a cached result, got %v", err)
+	}
+	if meta.Requests != 0 || meta.RateLimit.DayRemaining != 998 {
+		t.Errorf("expected no requests and the current stats for a cached result, got %d requests, %d remaining",
+			meta.Requests, meta.RateLimit.DayRemaining)
+	}
+}
+
 // TestKeywordAndManufacturerSearchMock tests V2 keyword+manufacturer search.
 func TestKeywordAndManufacturerSearchMock(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -513,6 +710,151 @@ func TestKeywordAndManufacturerSearchMock(t *testing.T) {
 	}
 }
 
+// TestSearchErrorOnEmptyMock tests that ErrorOnEmpty turns empty results into ErrNoResults.
+func TestSearchErrorOnEmptyMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Errors":[],"SearchResults":{"NumberOfResult":0,"Parts":[]}}`))
+	})
+
+	client := newTestClient(t, handler)
+	ctx := context.Background()
+
+	result, err := client.Search.KeywordSearch(ctx, SearchOptions{Keyword: "nothing"})
+	if err != nil {
+		t.Fatalf("expected no error by default, got %v", err)
+	}
+	if result.NumberOfResult != 0 {
+		t.Errorf("expected empty result, got %d", result.NumberOfResult)
+	}
+
+	_, err = client.Search.KeywordSearch(ctx, SearchOptions{Keyword: "nothing", ErrorOnEmpty: true})
+	if !errors.Is(err, ErrNoResults) {
+		t.Errorf("KeywordSearch: expected ErrNoResults, got %v", err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("ErrNoResults should be distinct from ErrNotFound")
+	}
+
+	_, err = client.Search.PartNumberSearch(ctx, PartNumberSearchOptions{PartNumber: "NOPE", ErrorOnEmpty: true})
+	if !errors.Is(err, ErrNoResults) {
+		t.Errorf("PartNumberSearch: expected ErrNoResults, got %v", err)
+	}
+
+	_, err = client.Search.KeywordAndManufacturerSearch(ctx, KeywordAndManufacturerSearchOptions{Keyword: "nothing", ErrorOnEmpty: true})
+	if !errors.Is(err, ErrNoResults) {
+		t.Errorf("KeywordAndManufacturerSearch: expected ErrNoResults, got %v", err)
+	}
+
+	_, err = client.Search.PartNumberAndManufacturerSearch(ctx, PartNumberAndManufacturerSearchOptions{PartNumber: "NOPE", ErrorOnEmpty: true})
+	if !errors.Is(err, ErrNoResults) {
+		t.Errorf("PartNumberAndManufacturerSearch: expected ErrNoResults, got %v", err)
+	}
+}
+
+// TestKeywordAndManufacturerSearchPageMock tests paging with a computed
+// StartingRecord and validation of the paging fields.
+func TestKeywordAndManufacturerSearchPageMock(t *testing.T) {
+	var gotPage, gotRecords int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req keywordAndManufacturerSearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to parse request: %v", err)
+		}
+		gotPage = req.SearchByKeywordMfrNameRequest.PageNumber
+		gotRecords = req.SearchByKeywordMfrNameRequest.Records
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Errors": [], "SearchResults": {"NumberOfResult": 120, "Parts": [{"MouserPartNumber": "TI-051"}]}}`))
+	})
+	client := newTestClient(t, handler)
+	ctx := context.Background()
+	opts := KeywordAndManufacturerSearchOptions{Keyword: "timer", ManufacturerName: "Texas Instruments", Records: 25, PageNumber: 7}
+
+	result, err := client.Search.KeywordAndManufacturerSearchPage(ctx, opts, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPage != 3 || gotRecords != 25 {
+		t.Errorf("expected page 3 of 25 records, sent page %d of %d", gotPage, gotRecords)
+	}
+	if result.StartingRecord != 50 {
+		t.Errorf("expected StartingRecord 50, got %d", result.StartingRecord)
+	}
+
+	opts.Records = 500
+	result, err = client.Search.KeywordAndManufacturerSearchPage(ctx, opts, 2)
+	if err != nil || gotRecords != MaxRecords || result.StartingRecord != MaxRecords {
+		t.Errorf("expected Records clamped to %d, sent %d with StartingRecord %d (err %v)", MaxRecords, gotRecords, result.StartingRecord, err)
+	}
+
+	invalid := []struct {
+		name string
+		opts KeywordAndManufacturerSearchOptions
+		page int
+	}{
+		{"zero page", opts, 0},
+		{"negative records", KeywordAndManufacturerSearchOptions{Keyword: "timer", Records: -1}, 1},
+		{"offset overflow", KeywordAndManufacturerSearchOptions{Keyword: "timer", Records: MaxRecords}, 1 << 30},
+	}
+	for _, tt := range invalid {
+		if _, err := client.Search.KeywordAndManufacturerSearchPage(ctx, tt.opts, tt.page); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: expected ErrInvalidRequest, got %v", tt.name, err)
+		}
+	}
+	if _, err := client.Search.KeywordAndManufacturerSearch(ctx, KeywordAndManufacturerSearchOptions{Keyword: "timer", PageNumber: -2}); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest for a negative PageNumber, got %v", err)
+	}
+}
+
+// TestPartNumberSearchTooManyPartsMock tests that more than MaxPartNumbers
+// part numbers are rejected before a request is sent.
+func TestPartNumberSearchTooManyPartsMock(t *testing.T) {
+	calls := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Errors":[],"SearchResults":{"NumberOfResult":0,"Parts":[]}}`))
+	})
+
+	client := newTestClient(t, handler)
+	parts := make([]string, 11)
+	for i := range parts {
+		parts[i] = fmt.Sprintf("PART-%02d", i)
+	}
+
+	_, err := client.Search.PartNumberSearch(context.Background(), PartNumberSearchOptions{
+		PartNumber: strings.Join(parts, "|"),
+	})
+	if !errors.Is(err, ErrTooManyParts) {
+		t.Fatalf("expected ErrTooManyParts, got %v", err)
+	}
+	var tooMany *TooManyPartsError
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("expected *TooManyPartsError, got %T", err)
+	}
+	if tooMany.Count != 11 || tooMany.Limit != MaxPartNumbers {
+		t.Errorf("expected Count=11 Limit=%d, got %+v", MaxPartNumbers, tooMany)
+	}
+
+	_, err = client.Search.PartNumberAndManufacturerSearch(context.Background(), PartNumberAndManufacturerSearchOptions{
+		PartNumber: strings.Join(parts, "|"),
+	})
+	if !errors.Is(err, ErrTooManyParts) {
+		t.Errorf("expected ErrTooManyParts from PartNumberAndManufacturerSearch, got %v", err)
+	}
+
+	if calls != 0 {
+		t.Errorf("expected no server calls, got %d", calls)
+	}
+
+	if _, err := client.Search.PartNumberSearch(context.Background(), PartNumberSearchOptions{
+		PartNumber: strings.Join(parts[:MaxPartNumbers], "|"),
+	}); err != nil {
+		t.Errorf("expected %d parts to be accepted, got %v", MaxPartNumbers, err)
+	}
+}
+
 // TestPartNumberAndManufacturerSearchMock tests V2 part number+manufacturer search.
 func TestPartNumberAndManufacturerSearchMock(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -631,6 +973,64 @@ func TestGetPartDetailsMock(t *testing.T) {
 	}
 }
 
+// TestPartDetailsCacheAgeMock tests that the age of cached part details is reported.
+func TestPartDetailsCacheAgeMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"Errors": [],
+			"SearchResults": {
+				"NumberOfResult": 1,
+				"Parts": [{"MouserPartNumber": "595-NE555P", "ManufacturerPartNumber": "NE555P"}]
+			}
+		}`))
+	})
+
+	client := newTestClientCached(t, handler)
+
+	if _, ok := client.PartDetailsCacheAge("595-NE555P"); ok {
+		t.Error("expected no cache age before lookup")
+	}
+
+	if _, err := client.Search.PartDetails(context.Background(), "595-NE555P"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first, ok := client.PartDetailsCacheAge("595-NE555P")
+	if !ok {
+		t.Fatal("expected cache age after lookup")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	second, ok := client.PartDetailsCacheAge("595-NE555P")
+	if !ok || second <= first {
+		t.Errorf("expected cache age to increase, got %v then %v", first, second)
+	}
+}
+
+// TestPartDetailsStaleSchemaRefetchMock tests that a part cached by an older
+// library version is refetched rather than decoded.
+func TestPartDetailsStaleSchemaRefetchMock(t *testing.T) {
+	var requests int
+	client := newTestClientCached(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Errors": [], "SearchResults": {"NumberOfResult": 1, "Parts": [{"MouserPartNumber": "595-NE555P", "Description": "fresh"}]}}`))
+	}))
+
+	stale := []byte("mouser-cache/v0\n" + `{"MouserPartNumber": "595-NE555P", "Description": "stale"}`)
+	client.cache.Set(cacheKeyForDetails("595-NE555P"), stale, time.Hour)
+
+	part, err := client.Search.PartDetails(context.Background(), "595-NE555P")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if part.Description != "fresh" || requests != 1 {
+		t.Errorf("expected a refetched part, got %q after %d requests", part.Description, requests)
+	}
+}
+
 // TestGetPartDetailsNotFoundMock tests GetPartDetails with no results.
 func TestGetPartDetailsNotFoundMock(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -645,6 +1045,213 @@ func TestGetPartDetailsNotFoundMock(t *testing.T) {
 	}
 }
 
+// manufacturerFallbackHandler returns nothing from the manufacturer-filtered
+// search and two manufacturers' parts from the plain part number search.
+func manufacturerFallbackHandler(t *testing.T, paths *[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search/partnumberandmanufacturer":
+			_, _ = w.Write([]byte(`{"Errors": [], "SearchResults": {"NumberOfResult": 0, "Parts": []}}`))
+		case "/search/partnumber":
+			_, _ = w.Write([]byte(`{"Errors": [], "SearchResults": {"NumberOfResult": 2, "Parts": [
+				{"MouserPartNumber": "512-LM317T", "ManufacturerPartNumber": "LM317T", "Manufacturer": "onsemi"},
+				{"MouserPartNumber": "595-LM317T", "ManufacturerPartNumber": "LM317T", "Manufacturer": "Texas Instruments"}
+			]}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+}
+
+// TestPartDetailsFuzzyMock tests the fallback from exact to non-exact
+// matching.
+func TestPartDetailsFuzzyMock(t *testing.T) {
+	var options []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req partNumberSearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to parse search request: %v", err)
+		}
+		opt := req.SearchByPartRequest.PartSearchOptions
+		options = append(options, opt)
+
+		var parts []Part
+		switch {
+		case req.SearchByPartRequest.MouserPartNumber == "LM317T" && opt == string(PartSearchOptionExact):
+			parts = []Part{{MouserPartNumber: "595-LM317T", ManufacturerPartNumber: "LM317T"}}
+		case req.SearchByPartRequest.MouserPartNumber == "LM3480IM3-5.0" && opt != string(PartSearchOptionExact):
+			parts = []Part{{MouserPartNumber: "926-LM3480IM350NOPB", ManufacturerPartNumber: "LM3480IM3-5.0/NOPB"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(searchResponse{SearchResults: SearchResult{NumberOfResult: len(parts), Parts: parts}})
+	})
+	client := newTestClient(t, handler)
+	ctx := context.Background()
+
+	part, fuzzy, err := client.Search.PartDetailsFuzzy(ctx, "LM317T")
+	if err != nil || fuzzy || part.MouserPartNumber != "595-LM317T" {
+		t.Errorf("exact match: got %v, fuzzy=%v, err=%v", part, fuzzy, err)
+	}
+	if len(options) != 1 {
+		t.Errorf("expected no fallback search after an exact match, got %v", options)
+	}
+
+	part, fuzzy, err = client.Search.PartDetailsFuzzy(ctx, "LM3480IM3-5.0")
+	if err != nil || !fuzzy || part.ManufacturerPartNumber != "LM3480IM3-5.0/NOPB" {
+		t.Errorf("fuzzy match: got %v, fuzzy=%v, err=%v", part, fuzzy, err)
+	}
+
+	_, _, err = client.Search.PartDetailsFuzzy(ctx, "NOPE-1")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestProductDetailMock tests exact, single-result, ambiguous, and missing
+// product detail lookups.
+func TestProductDetailMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req partNumberSearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to parse search request: %v", err)
+		}
+		if opt := req.SearchByPartRequest.PartSearchOptions; opt != string(PartSearchOptionExact) {
+			t.Errorf("expected exact search, got %q", opt)
+		}
+
+		var parts []Part
+		switch req.SearchByPartRequest.MouserPartNumber {
+		case "595-NE555DR":
+			parts = []Part{{MouserPartNumber: "595-NE555DRG4"}, {MouserPartNumber: "595-NE555DR"}}
+		case "NE555P":
+			parts = []Part{{MouserPartNumber: "595-NE555P", ManufacturerPartNumber: "NE555P"}}
+		case "NE555":
+			parts = []Part{{MouserPartNumber: "595-NE555P"}, {MouserPartNumber: "511-NE555N"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(searchResponse{SearchResults: SearchResult{NumberOfResult: len(parts), Parts: parts}})
+	})
+	client := newTestClient(t, handler)
+	ctx := context.Background()
+
+	part, err := client.Search.ProductDetail(ctx, "595-NE555DR")
+	if err != nil || part.MouserPartNumber != "595-NE555DR" {
+		t.Errorf("exact match: got %v, err=%v", part, err)
+	}
+
+	part, err = client.Search.ProductDetail(ctx, "NE555P")
+	if err != nil || part.MouserPartNumber != "595-NE555P" {
+		t.Errorf("single result: got %v, err=%v", part, err)
+	}
+
+	_, err = client.Search.ProductDetail(ctx, "NE555")
+	var ambiguous *AmbiguousPartError
+	if !errors.Is(err, ErrAmbiguousPart) || !errors.As(err, &ambiguous) || len(ambiguous.Candidates) != 2 {
+		t.Errorf("expected AmbiguousPartError with 2 candidates, got %v", err)
+	}
+
+	_, err = client.Search.ProductDetail(ctx, "NOPE-1")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestSuggestedReplacementMock tests following a part's suggested
+// replacement, preferring the same manufacturer.
+func TestSuggestedReplacementMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var parts []Part
+		if strings.Contains(r.URL.Path, "partnumberandmanufacturer") {
+			var req partNumberAndManufacturerSearchRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if q := req.SearchByPartMfrNameRequest; q.MouserPartNumber == "LM555CN" && q.ManufacturerName == "Texas Instruments" {
+				parts = []Part{{MouserPartNumber: "926-LM555CN", Manufacturer: "Texas Instruments"}}
+			}
+		} else {
+			var req partNumberSearchRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.SearchByPartRequest.MouserPartNumber == "ICM7555" {
+				parts = []Part{{MouserPartNumber: "968-ICM7555", Manufacturer: "Renesas"}}
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(searchResponse{SearchResults: SearchResult{NumberOfResult: len(parts), Parts: parts}})
+	})
+	client := newTestClient(t, handler)
+	ctx := context.Background()
+
+	part, err := client.Search.SuggestedReplacement(ctx, Part{MouserPartNumber: "595-OLD", Manufacturer: "Texas Instruments", SuggestedReplacement: " LM555CN "})
+	if err != nil || part.MouserPartNumber != "926-LM555CN" {
+		t.Errorf("same manufacturer: got %v, err=%v", part, err)
+	}
+
+	part, err = client.Search.SuggestedReplacement(ctx, Part{MouserPartNumber: "595-OLD", Manufacturer: "Texas Instruments", SuggestedReplacement: "ICM7555"})
+	if err != nil || part.MouserPartNumber != "968-ICM7555" {
+		t.Errorf("other manufacturer: got %v, err=%v", part, err)
+	}
+
+	if _, err := client.Search.SuggestedReplacement(ctx, Part{MouserPartNumber: "595-OLD"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound without a replacement, got %v", err)
+	}
+	if _, err := client.Search.SuggestedReplacement(ctx, Part{MouserPartNumber: "595-OLD", SuggestedReplacement: "GONE"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a missing replacement, got %v", err)
+	}
+}
+
+// TestPartDetailsWithManufacturerFallbackMock tests that an empty
+// manufacturer search falls back to a part number search filtered by name.
+func TestPartDetailsWithManufacturerFallbackMock(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(manufacturerFallbackHandler(t, &paths))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithManufacturerSearchFallback(true),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	part, source, err := client.Search.PartDetailsWithManufacturerSource(context.Background(), "LM317T", "Texas Instruments Inc.")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if part.MouserPartNumber != "595-LM317T" {
+		t.Errorf("expected the Texas Instruments part, got %s (%s)", part.MouserPartNumber, part.Manufacturer)
+	}
+	if source != LookupSourcePartNumberFallback {
+		t.Errorf("expected source %q, got %q", LookupSourcePartNumberFallback, source)
+	}
+	if len(paths) != 2 || paths[0] != "/search/partnumberandmanufacturer" || paths[1] != "/search/partnumber" {
+		t.Errorf("unexpected request sequence: %v", paths)
+	}
+
+	_, _, err = client.Search.PartDetailsWithManufacturerSource(context.Background(), "LM317T", "Analog Devices")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound when no fallback part matches, got %v", err)
+	}
+}
+
+// TestPartDetailsWithManufacturerNoFallbackMock tests that the fallback is off by default.
+func TestPartDetailsWithManufacturerNoFallbackMock(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, manufacturerFallbackHandler(t, &paths))
+
+	_, err := client.Search.PartDetailsWithManufacturer(context.Background(), "LM317T", "Texas Instruments")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if len(paths) != 1 {
+		t.Errorf("expected only the manufacturer search, got %v", paths)
+	}
+}
+
 // TestSearchAllMock tests the SearchAll paginated iterator with mock data.
 func TestSearchAllMock(t *testing.T) {
 	page := 0
@@ -718,3 +1325,133 @@ func TestSearchHTTPErrorMock(t *testing.T) {
 		t.Fatal("expected error for 500 response")
 	}
 }
+
+// TestSearchAllDeadlineMock tests that All stops at the context deadline and
+// returns the deadline error after delivering the parts fetched so far.
+func TestSearchAllDeadlineMock(t *testing.T) {
+	page := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page++
+		_, _ = io.Copy(io.Discard, r.Body)
+		if page > 1 {
+			// Stall until the client gives up.
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+			return
+		}
+
+		parts := ""
+		for i := 0; i < MaxRecords; i++ {
+			if i > 0 {
+				parts += ","
+			}
+			parts += `{"MouserPartNumber":"P-` + string(rune('A'+i%26)) + `"}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Errors":[],"SearchResults":{"NumberOfResult":500,"Parts":[` + parts + `]}}`))
+	})
+
+	client := newTestClient(t, handler)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	var collected int
+	err := client.Search.All(ctx, SearchOptions{Keyword: "test"}, func(p Part) bool {
+		collected++
+		return true
+	})
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if collected != MaxRecords {
+		t.Errorf("expected %d parts from the first page, got %d", MaxRecords, collected)
+	}
+	if elapsed > time.Second {
+		t.Errorf("All did not return promptly at the deadline: %v", elapsed)
+	}
+}
+
+// TestSmartSearchMock tests merging of keyword and part number results with
+// exact part matches ranked first.
+func TestSmartSearchMock(t *testing.T) {
+	var mu sync.Mutex
+	paths := map[string]int{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths[r.URL.Path]++
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search/keyword":
+			_, _ = w.Write([]byte(`{"Errors":[],"SearchResults":{"NumberOfResult":3,"Parts":[
+				{"MouserPartNumber":"511-LM317T-DG","ManufacturerPartNumber":"LM317T-DG"},
+				{"MouserPartNumber":"595-LM317T","ManufacturerPartNumber":"LM317T"},
+				{"MouserPartNumber":"926-LM317MDTX","ManufacturerPartNumber":"LM317MDT/NOPB"}
+			]}}`))
+		case "/search/partnumber":
+			_, _ = w.Write([]byte(`{"Errors":[],"SearchResults":{"NumberOfResult":2,"Parts":[
+				{"MouserPartNumber":"595-LM317TG","ManufacturerPartNumber":"LM317TG"},
+				{"MouserPartNumber":"595-LM317T","ManufacturerPartNumber":"LM317T"}
+			]}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	client := newTestClient(t, handler)
+	result, err := client.Search.SmartSearch(context.Background(), "lm317t", SearchOptions{Records: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if paths["/search/keyword"] != 1 || paths["/search/partnumber"] != 1 {
+		t.Errorf("expected one keyword and one part number request, got %v", paths)
+	}
+
+	want := []string{"595-LM317T", "595-LM317TG", "511-LM317T-DG", "926-LM317MDTX"}
+	if result.NumberOfResult != len(want) || len(result.Parts) != len(want) {
+		t.Fatalf("expected %d merged parts, got %d (%+v)", len(want), len(result.Parts), result.Parts)
+	}
+	for i, pn := range want {
+		if result.Parts[i].MouserPartNumber != pn {
+			t.Errorf("Parts[%d] = %s, want %s", i, result.Parts[i].MouserPartNumber, pn)
+		}
+	}
+}
+
+// TestSmartSearchPartialFailureMock tests that one failing search still
+// yields results, together with a *PartialSearchError naming it.
+func TestSmartSearchPartialFailureMock(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/search/partnumber" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Errors":[],"SearchResults":{"NumberOfResult":1,"Parts":[{"MouserPartNumber":"KW-1"}]}}`))
+	})
+
+	client := newTestClient(t, handler)
+	result, err := client.Search.SmartSearch(context.Background(), "capacitor", SearchOptions{})
+	var partialErr *PartialSearchError
+	if !errors.As(err, &partialErr) || partialErr.Search != "part number" {
+		t.Fatalf("expected a *PartialSearchError for the part number search, got %v", err)
+	}
+	var mErr *MouserError
+	if !errors.As(err, &mErr) || mErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected the 500 to be wrapped, got %v", err)
+	}
+	if result == nil {
+		t.Fatal("expected the keyword results")
+	}
+	if len(result.Parts) != 1 || result.Parts[0].MouserPartNumber != "KW-1" {
+		t.Errorf("unexpected parts: %+v", result.Parts)
+	}
+}
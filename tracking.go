@@ -0,0 +1,124 @@
+package mouser
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Carriers recognized by Tracking.Carrier.
+const (
+	CarrierFedEx = "FedEx"
+	CarrierUPS   = "UPS"
+	CarrierUSPS  = "USPS"
+	CarrierDHL   = "DHL"
+)
+
+// carrierTrackingURLs are tracking page templates keyed by carrier; %s is
+// replaced with the query-escaped tracking number.
+var carrierTrackingURLs = map[string]string{
+	CarrierFedEx: "https://www.fedex.com/fedextrack/?trknbr=%s",
+	CarrierUPS:   "https://www.ups.com/track?tracknum=%s",
+	CarrierUSPS:  "https://tools.usps.com/go/TrackConfirmAction?tLabels=%s",
+	CarrierDHL:   "https://www.dhl.com/global-en/home/tracking/tracking-express.html?tracking-id=%s",
+}
+
+// Tracking number formats, checked in order. USPS is checked before FedEx
+// because 22-digit USPS numbers would otherwise look like FedEx ones.
+var trackingNumberFormats = []struct {
+	carrier string
+	pattern *regexp.Regexp
+}{
+	{CarrierUPS, regexp.MustCompile(`^1Z[0-9A-Z]{16}$`)},
+	{CarrierUSPS, regexp.MustCompile(`^(9[1-5][0-9]{18,20}|[A-Z]{2}[0-9]{9}US)$`)},
+	{CarrierFedEx, regexp.MustCompile(`^([0-9]{12}|[0-9]{15})$`)},
+	{CarrierDHL, regexp.MustCompile(`^[0-9]{10}$`)},
+}
+
+// Carrier infers the shipping carrier from the tracking link's host or,
+// without a link, from the tracking number format: "1Z" numbers are UPS,
+// 20-22 digit numbers starting with 91-95 and "XX123456789US" numbers are
+// USPS, 12 and 15 digit numbers are FedEx, and 10 digit numbers are DHL.
+// It returns one of the Carrier constants, or "" if the carrier is unknown.
+// Delivery.TrackingURLs can also use the shipping method name.
+func (t Tracking) Carrier() string {
+	if t.Link != "" {
+		if u, err := url.Parse(t.Link); err == nil {
+			if carrier := carrierFromName(u.Hostname()); carrier != "" {
+				return carrier
+			}
+		}
+	}
+
+	number := normalizeTrackingNumber(t.Number)
+	for _, format := range trackingNumberFormats {
+		if format.pattern.MatchString(number) {
+			return format.carrier
+		}
+	}
+	return ""
+}
+
+// URL returns Link if Mouser provided one, and otherwise builds a tracking
+// page URL from Number for the carrier reported by Carrier. It returns ""
+// if there is no link and the carrier is unknown.
+func (t Tracking) URL() string {
+	return t.urlFor(t.Carrier())
+}
+
+// urlFor returns Link, or a tracking page URL for carrier.
+func (t Tracking) urlFor(carrier string) string {
+	if t.Link != "" {
+		return t.Link
+	}
+	number := normalizeTrackingNumber(t.Number)
+	template, ok := carrierTrackingURLs[carrier]
+	if !ok || number == "" {
+		return ""
+	}
+	return fmt.Sprintf(template, url.QueryEscape(number))
+}
+
+// TrackingURLs returns a URL for each of the delivery's TrackingDetails, in
+// order, as Tracking.URL does. When a number's format does not identify the
+// carrier, the carrier is taken from ShippingMethodName (e.g. "FedEx
+// Ground"). Entries whose carrier cannot be determined are "".
+func (d Delivery) TrackingURLs() []string {
+	methodCarrier := carrierFromName(d.ShippingMethodName)
+
+	urls := make([]string, len(d.TrackingDetails))
+	for i, t := range d.TrackingDetails {
+		carrier := t.Carrier()
+		if carrier == "" {
+			carrier = methodCarrier
+		}
+		urls[i] = t.urlFor(carrier)
+	}
+	return urls
+}
+
+// carrierFromName recognizes a carrier in a shipping method name or host.
+func carrierFromName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9')
+	})
+	for _, w := range words {
+		switch w {
+		case "fedex":
+			return CarrierFedEx
+		case "ups":
+			return CarrierUPS
+		case "usps":
+			return CarrierUSPS
+		case "dhl":
+			return CarrierDHL
+		}
+	}
+	return ""
+}
+
+// normalizeTrackingNumber removes spaces and dashes and uppercases letters.
+func normalizeTrackingNumber(number string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(number)))
+}
@@ -0,0 +1,80 @@
+package mouser
+
+import "testing"
+
+// TestTrackingCarrier tests carrier detection from links and number formats.
+func TestTrackingCarrier(t *testing.T) {
+	testCases := []struct {
+		tracking Tracking
+		want     string
+	}{
+		{Tracking{Number: "1Z999AA10123456784"}, CarrierUPS},
+		{Tracking{Number: "1z 999 aa1 0123456784"}, CarrierUPS},
+		{Tracking{Number: "9400111899223197428490"}, CarrierUSPS},
+		{Tracking{Number: "EC123456789US"}, CarrierUSPS},
+		{Tracking{Number: "123456789012"}, CarrierFedEx},
+		{Tracking{Number: "123456789012345"}, CarrierFedEx},
+		{Tracking{Number: "1234567890"}, CarrierDHL},
+		{Tracking{Number: "ABC"}, ""},
+		{Tracking{Number: "ABC", Link: "https://www.ups.com/track?tracknum=ABC"}, CarrierUPS},
+		{Tracking{Number: "1234567890", Link: "https://www.fedex.com/track/1234567890"}, CarrierFedEx},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.tracking.Carrier(); got != tc.want {
+			t.Errorf("%+v: expected carrier %q, got %q", tc.tracking, tc.want, got)
+		}
+	}
+}
+
+// TestTrackingURL tests that Link is preferred and otherwise built from the number.
+func TestTrackingURL(t *testing.T) {
+	testCases := []struct {
+		tracking Tracking
+		want     string
+	}{
+		{Tracking{Number: "1Z999AA10123456784", Link: "https://mouser.test/track"}, "https://mouser.test/track"},
+		{Tracking{Number: "1Z999AA10123456784"}, "https://www.ups.com/track?tracknum=1Z999AA10123456784"},
+		{Tracking{Number: "1234 5678 9012"}, "https://www.fedex.com/fedextrack/?trknbr=123456789012"},
+		{Tracking{Number: "EC123456789US"}, "https://tools.usps.com/go/TrackConfirmAction?tLabels=EC123456789US"},
+		{Tracking{Number: "ABC"}, ""},
+		{Tracking{}, ""},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.tracking.URL(); got != tc.want {
+			t.Errorf("%+v: expected URL %q, got %q", tc.tracking, tc.want, got)
+		}
+	}
+}
+
+// TestDeliveryTrackingURLs tests the shipping method name fallback.
+func TestDeliveryTrackingURLs(t *testing.T) {
+	delivery := Delivery{
+		ShippingMethodName: "UPS Ground",
+		TrackingDetails: []Tracking{
+			{Number: "ABC123"},
+			{Number: "123456789012"},
+			{Number: "X", Link: "https://mouser.test/track/X"},
+		},
+	}
+
+	urls := delivery.TrackingURLs()
+	want := []string{
+		"https://www.ups.com/track?tracknum=ABC123",
+		"https://www.fedex.com/fedextrack/?trknbr=123456789012",
+		"https://mouser.test/track/X",
+	}
+	if len(urls) != len(want) {
+		t.Fatalf("expected %d URLs, got %v", len(want), urls)
+	}
+	for i := range want {
+		if urls[i] != want[i] {
+			t.Errorf("URL %d: expected %q, got %q", i, want[i], urls[i])
+		}
+	}
+
+	if urls := (Delivery{ShippingMethodName: "Mouser Economy", TrackingDetails: []Tracking{{Number: "ABC"}}}).TrackingURLs(); urls[0] != "" {
+		t.Errorf("expected empty URL for unknown carrier, got %q", urls[0])
+	}
+}
@@ -4,17 +4,23 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
-// buildURL constructs a URL with the API key as a query parameter.
+// buildURL constructs a URL with the API key as a query parameter. The
+// Mouser API accepts the key in no other way, so every URL that may end up
+// in an error or log must go through redactAPIKey.
 func (c *Client) buildURL(path string) (string, error) {
-	u, err := url.Parse(c.baseURL + path)
+	u, err := url.Parse(c.apiBaseURL(path) + path)
 	if err != nil {
 		return "", fmt.Errorf("mouser: invalid URL: %w", err)
 	}
@@ -26,35 +32,94 @@ func (c *Client) buildURL(path string) (string, error) {
 	return u.String(), nil
 }
 
+// redactAPIKey returns rawURL with the apiKey query parameter replaced by
+// "REDACTED".
+func redactAPIKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	q := u.Query()
+	if q.Has("apiKey") {
+		q.Set("apiKey", "REDACTED")
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
+
 // doRequest performs an HTTP request with rate limiting, retries, and error handling.
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
-	return c.doWithRetry(ctx, method, path, nil, body, result)
+	return c.doRequestWithQuery(ctx, method, path, nil, body, result)
 }
 
 // doRequestWithQuery performs an HTTP request with additional URL query parameters.
 func (c *Client) doRequestWithQuery(ctx context.Context, method, path string, query url.Values, body interface{}, result interface{}) error {
-	return c.doWithRetry(ctx, method, path, query, body, result)
+	err := c.doWithRetry(ctx, method, path, query, body, result)
+	// Requests abandoned by the caller say nothing about the API's health.
+	if err != nil && !errors.Is(err, context.Canceled) {
+		c.recentErrors.record()
+	}
+	return err
 }
 
 // doWithRetry performs an HTTP request with retry logic.
 func (c *Client) doWithRetry(ctx context.Context, method, path string, query url.Values, body interface{}, result interface{}) error {
+	// context.WithTimeout keeps an earlier deadline the caller already set.
+	if timeout := c.requestTimeout(path); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	var lastErr error
+	var lastRetryAfter int
+	var lastBackoff time.Duration
+	started := time.Now()
 	maxAttempts := c.retryConfig.MaxRetries + 1
+	if retriesDisabled(ctx) {
+		maxAttempts = 1
+	}
 
 	for attempt := 0; attempt < maxAttempts; attempt++ {
+		// Don't spend a rate limit token on a request that can't complete.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		if attempt > 0 {
-			backoff := c.retryConfig.calculateBackoff(attempt - 1)
+			lastBackoff = c.retryConfig.calculateBackoff(attempt-1, lastBackoff)
+			backoff := retryBackoff(lastBackoff, lastRetryAfter)
+			// Report the real failure rather than waiting out a deadline
+			// that would expire before the next attempt.
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+				return lastErr
+			}
+			if limit := c.retryConfig.MaxElapsedTime; limit > 0 && time.Since(started)+backoff > limit {
+				return lastErr
+			}
 			if err := sleep(ctx, backoff); err != nil {
 				return err
 			}
 		}
 
+		if c.circuitBreaker != nil {
+			if err := c.circuitBreaker.allow(); err != nil {
+				return err
+			}
+		}
+
+		start := time.Now()
 		statusCode, retryAfter, err := c.doOnce(ctx, method, path, query, body, result)
+		c.logAttempt(ctx, method, path, attempt+1, statusCode, time.Since(start), err)
+		if c.circuitBreaker != nil {
+			c.circuitBreaker.record(ctx, err, statusCode)
+		}
 		if err == nil {
 			return nil
 		}
 
 		lastErr = err
+		lastRetryAfter = retryAfter
 
 		// Update rate limiter if we got a Retry-After header
 		if retryAfter > 0 {
@@ -62,7 +127,18 @@ func (c *Client) doWithRetry(ctx context.Context, method, path string, query url
 		}
 
 		// Check if we should retry
-		if !shouldRetry(err, statusCode) {
+		if !c.retryConfig.retryable(err, statusCode) {
+			return err
+		}
+
+		// A cart or order change that failed in transit may still have been
+		// applied, so resending it could, e.g., double a cart insert.
+		if isUnansweredRequest(err, statusCode) && isMutatingRequest(method, path) {
+			return err
+		}
+
+		// Keep the last of the daily quota for requests that can succeed.
+		if c.quotaRetryThreshold > 0 && c.rateLimiter.Stats().DayRemaining < c.quotaRetryThreshold {
 			return err
 		}
 
@@ -75,11 +151,130 @@ func (c *Client) doWithRetry(ctx context.Context, method, path string, query url
 	return lastErr
 }
 
+// isUnansweredRequest reports whether an attempt failed in the HTTP round
+// trip, after the request may have reached the API, without a response.
+func isUnansweredRequest(err error, statusCode int) bool {
+	var urlErr *url.Error
+	return statusCode == 0 && errors.As(err, &urlErr)
+}
+
+// isMutatingRequest reports whether a request changes a cart or places an
+// order. Searches and the order options query are POSTs too, but read-only.
+func isMutatingRequest(method, path string) bool {
+	if method != http.MethodPost {
+		return false
+	}
+	switch {
+	case path == "/cart" || strings.HasPrefix(path, "/cart/"):
+		return true
+	case path == "/order/options/query":
+		return false
+	case path == "/order" || strings.HasPrefix(path, "/order/"):
+		return true
+	}
+	return false
+}
+
+// dryRunRequest stands in for a mutating request under WithDryRun: it logs
+// the request that would have been sent and reports success without
+// touching the network or the rate limiter, leaving the result unset.
+func (c *Client) dryRunRequest(ctx context.Context, method, path string, query url.Values, body interface{}) (int, int, error) {
+	if c.logger != nil {
+		attrs := []slog.Attr{
+			slog.String("method", method),
+			slog.String("path", path),
+		}
+		if len(query) > 0 {
+			attrs = append(attrs, slog.String("query", query.Encode()))
+		}
+		if body != nil {
+			jsonBody, err := json.Marshal(body)
+			if err != nil {
+				return 0, 0, fmt.Errorf("mouser: failed to marshal request: %w", err)
+			}
+			attrs = append(attrs, slog.String("body", string(jsonBody)))
+		}
+		attrs = append(attrs, logFields(ctx)...)
+		c.logger.LogAttrs(ctx, slog.LevelInfo, "mouser dry run: request not sent", attrs...)
+	}
+	return http.StatusOK, 0, nil
+}
+
+// acquireSlot waits for one of the in-flight request slots set by
+// WithMaxConcurrency and returns a function that releases it. Without a
+// limit it returns immediately.
+func (c *Client) acquireSlot(ctx context.Context) (func(), error) {
+	if c.inflight == nil {
+		return func() {}, nil
+	}
+	select {
+	case c.inflight <- struct{}{}:
+		return func() { <-c.inflight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// logAttempt logs a request attempt if the client has a logger.
+func (c *Client) logAttempt(ctx context.Context, method, path string, attempt, statusCode int, duration time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	level := slog.LevelDebug
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("path", path),
+		slog.Int("attempt", attempt),
+		slog.Int("status", statusCode),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", string(c.redactKey([]byte(err.Error())))))
+	}
+	if id := requestIDFrom(ctx); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	attrs = append(attrs, logFields(ctx)...)
+
+	c.logger.LogAttrs(ctx, level, "mouser request", attrs...)
+}
+
+// setRequestIDHeader sends ctx's WithRequestID correlation ID in the header
+// configured with WithRequestIDHeader.
+func (c *Client) setRequestIDHeader(ctx context.Context, req *http.Request) {
+	if id := requestIDFrom(ctx); id != "" && c.requestIDHeader != "" {
+		req.Header.Set(c.requestIDHeader, id)
+	}
+}
+
+// retryBackoff returns the delay before the next attempt: the calculated
+// backoff, or the server's Retry-After if that is longer. Retry-After is
+// capped at the same 5 minutes the rate limiter applies.
+func retryBackoff(calculated time.Duration, retryAfterSeconds int) time.Duration {
+	if retryAfterSeconds > 300 {
+		retryAfterSeconds = 300
+	}
+	if serverBackoff := time.Duration(retryAfterSeconds) * time.Second; serverBackoff > calculated {
+		return serverBackoff
+	}
+	return calculated
+}
+
 // doOnce performs a single HTTP request attempt.
 // Returns (statusCode, retryAfterSeconds, error).
 func (c *Client) doOnce(ctx context.Context, method, path string, query url.Values, body interface{}, result interface{}) (int, int, error) {
-	// Check rate limiter (non-blocking)
-	if err := c.rateLimiter.Allow(); err != nil {
+	if c.dryRun && isMutatingRequest(method, path) {
+		return c.dryRunRequest(ctx, method, path, query, body)
+	}
+
+	// Check rate limiter (non-blocking unless the caller opted to wait)
+	if waitsForRateLimit(ctx) {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return 0, 0, err
+		}
+	} else if err := c.rateLimiter.Allow(); err != nil {
 		return 0, 0, err
 	}
 
@@ -97,18 +292,27 @@ func (c *Client) doOnce(ctx context.Context, method, path string, query url.Valu
 		}
 		q := u.Query()
 		for k, vs := range query {
+			// The API key is set by buildURL and must appear exactly once.
+			if k == "apiKey" {
+				continue
+			}
 			for _, v := range vs {
-				q.Set(k, v)
+				q.Add(k, v)
 			}
 		}
 		u.RawQuery = q.Encode()
 		reqURL = u.String()
 	}
 
+	if c.urlAuditor != nil {
+		c.urlAuditor(method, redactAPIKey(reqURL))
+	}
+
 	// Marshal request body
 	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return 0, 0, fmt.Errorf("mouser: failed to marshal request: %w", err)
 		}
@@ -124,75 +328,341 @@ func (c *Client) doOnce(ctx context.Context, method, path string, query url.Valu
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.language != "" {
+		req.Header.Set("Accept-Language", c.language)
+	}
+	if c.compression {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	for key, values := range c.defaultHeaders {
+		req.Header[key] = values
+	}
+	c.setRequestIDHeader(ctx, req)
+
+	// Wait for an in-flight slot if concurrency is limited
+	release, err := c.acquireSlot(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
 
 	// Perform request
-	resp, err := c.httpClient.Do(req)
+	if call := callStatsFrom(ctx); call != nil {
+		call.sent++
+	}
+	resp, err := c.httpClientFor(path).Do(req)
 	if err != nil {
+		release()
+		// The *url.Error message includes the full URL, and with it the key.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = redactAPIKey(urlErr.URL)
+		}
+		c.audit(path, jsonBody, nil)
 		return 0, 0, fmt.Errorf("mouser: request failed: %w", err)
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
+	if err := decompressBody(resp); err != nil {
+		release()
+		return resp.StatusCode, 0, err
+	}
+
+	if c.streamsResponse(resp.StatusCode, result) {
+		defer release()
+		c.syncRateLimit(ctx, resp.Header)
+		return resp.StatusCode, 0, c.decodeStream(ctx, path, resp.Body, result)
+	}
+
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
+	release()
+	c.audit(path, jsonBody, respBody)
 	if err != nil {
 		return resp.StatusCode, 0, fmt.Errorf("mouser: failed to read response: %w", err)
 	}
 
 	// Sync rate limiter from response headers on every response.
-	c.rateLimiter.UpdateFromHeaders(resp.Header)
+	c.syncRateLimit(ctx, resp.Header)
 
 	// Parse Retry-After header
 	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
 
+	// Error fields keep a copy of the body; redact the key in case Mouser
+	// echoes it back, before truncation can split it.
+	errBody := c.redactKey(respBody)
+	rawBody, truncated := c.rawBody(errBody)
+
 	// Handle rate limiting (429)
 	if resp.StatusCode == http.StatusTooManyRequests {
 		return resp.StatusCode, retryAfter, &MouserError{
-			StatusCode:  resp.StatusCode,
-			Message:     "rate limit exceeded",
-			Details:     string(respBody),
-			Endpoint:    path,
-			RetryAfter:  retryAfter,
-			IsRetryable: true,
+			StatusCode:       resp.StatusCode,
+			Message:          "rate limit exceeded",
+			Details:          string(errBody),
+			Endpoint:         path,
+			RequestID:        requestIDFrom(ctx),
+			RetryAfter:       retryAfter,
+			IsRetryable:      true,
+			RawBody:          rawBody,
+			RawBodyTruncated: truncated,
 		}
 	}
 
 	// Check for HTTP errors
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return resp.StatusCode, retryAfter, &MouserError{
-			StatusCode:  resp.StatusCode,
-			Message:     http.StatusText(resp.StatusCode),
-			Details:     string(respBody),
-			Endpoint:    path,
-			IsRetryable: shouldRetry(nil, resp.StatusCode),
+			StatusCode:       resp.StatusCode,
+			Message:          http.StatusText(resp.StatusCode),
+			Details:          string(errBody),
+			Endpoint:         path,
+			RequestID:        requestIDFrom(ctx),
+			RetryAfter:       retryAfter,
+			IsRetryable:      shouldRetry(nil, resp.StatusCode),
+			RawBody:          rawBody,
+			RawBodyTruncated: truncated,
 		}
 	}
 
 	// Unmarshal response
 	if result != nil {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return resp.StatusCode, 0, fmt.Errorf("mouser: failed to parse response: %w", err)
+		if err := c.decode(ctx, respBody, result); err != nil {
+			if errors.Is(err, ErrDecodeTimeout) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return resp.StatusCode, 0, err
+			}
+			return resp.StatusCode, 0, &MouserError{
+				StatusCode:       resp.StatusCode,
+				Message:          "failed to parse response: " + err.Error(),
+				Endpoint:         path,
+				RequestID:        requestIDFrom(ctx),
+				RawBody:          rawBody,
+				RawBodyTruncated: truncated,
+			}
 		}
 	}
 
 	return resp.StatusCode, 0, nil
 }
 
-// getCached retrieves a cached response if available.
-func (c *Client) getCached(key string) ([]byte, bool) {
-	if c.cache == nil || !c.cacheConfig.Enabled {
+// syncRateLimit updates the rate limiter from a response's headers and, if
+// ctx collects call stats, records the request and the resulting snapshot.
+func (c *Client) syncRateLimit(ctx context.Context, headers http.Header) {
+	stats := c.rateLimiter.syncHeaders(headers)
+	if call := callStatsFrom(ctx); call != nil {
+		call.requests++
+		call.rateLimit = stats
+		call.captured = true
+	}
+}
+
+// streamsResponse reports whether a response should be decoded straight
+// from the body instead of being read into memory first. Only successful
+// responses with a result are streamed, and only without an audit hook,
+// which needs the whole body.
+func (c *Client) streamsResponse(statusCode int, result interface{}) bool {
+	return c.streamingDecode && result != nil && c.auditHook == nil &&
+		statusCode >= 200 && statusCode < 300
+}
+
+// decodeStream decodes a successful response from body with a json.Decoder.
+// The first maxRawBodySize bytes, plus enough to finish an API key cut off
+// at the limit, are kept so a decode failure still reports a redacted
+// RawBody.
+func (c *Client) decodeStream(ctx context.Context, path string, body io.Reader, result interface{}) error {
+	prefix := &cappedBuffer{}
+	if c.maxRawBodySize > 0 {
+		prefix.limit = c.maxRawBodySize + max(len(c.apiKey)-1, 0)
+	}
+	reader := &errReader{r: io.TeeReader(body, prefix)}
+
+	err := c.decodeWith(ctx, result, func(v interface{}) error {
+		return json.NewDecoder(reader).Decode(v)
+	})
+	if err == nil {
+		// Drain trailing whitespace so the connection can be reused.
+		_, _ = io.Copy(io.Discard, io.LimitReader(body, 4096))
+		return nil
+	}
+	if errors.Is(err, ErrDecodeTimeout) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	if reader.err != nil {
+		return fmt.Errorf("mouser: failed to read response: %w", reader.err)
+	}
+	kept := prefix.buf.Bytes()
+	if prefix.truncated {
+		kept = trimPartialKey(kept, c.apiKey)
+	}
+	rawBody, truncated := c.rawBody(c.redactKey(kept))
+	return &MouserError{
+		StatusCode:       http.StatusOK,
+		Message:          "failed to parse response: " + err.Error(),
+		Endpoint:         path,
+		RequestID:        requestIDFrom(ctx),
+		RawBody:          rawBody,
+		RawBodyTruncated: truncated || (rawBody != nil && prefix.truncated),
+	}
+}
+
+// trimPartialKey drops the end of b if it is the start of key, which the
+// rest of a cut-off body might have completed.
+func trimPartialKey(b []byte, key string) []byte {
+	for n := min(len(key)-1, len(b)); n > 0; n-- {
+		if bytes.HasSuffix(b, []byte(key[:n])) {
+			return b[:len(b)-n]
+		}
+	}
+	return b
+}
+
+// cappedBuffer is an io.Writer that keeps only the first limit bytes
+// written to it.
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room < len(p) {
+		b.truncated = true
+		b.buf.Write(p[:max(room, 0)])
+	} else {
+		b.buf.Write(p)
+	}
+	return len(p), nil
+}
+
+// errReader records the first read error other than io.EOF, so transport
+// failures can be told apart from malformed JSON.
+type errReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil && err != io.EOF && r.err == nil {
+		r.err = err
+	}
+	return n, err
+}
+
+// decode unmarshals body into result, honoring the decode timeout.
+func (c *Client) decode(ctx context.Context, body []byte, result interface{}) error {
+	return c.decodeWith(ctx, result, func(v interface{}) error {
+		return json.Unmarshal(body, v)
+	})
+}
+
+// decodeWith decodes into result with unmarshal. With a decode timeout
+// configured, it decodes into a fresh value on another goroutine and copies
+// it into result only if decoding finishes in time, so an abandoned decode
+// never writes to result concurrently with the caller.
+func (c *Client) decodeWith(ctx context.Context, result interface{}, unmarshal func(v interface{}) error) error {
+	if c.decodeTimeout <= 0 {
+		return unmarshal(result)
+	}
+
+	rv := reflect.ValueOf(result)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return unmarshal(result)
+	}
+
+	fresh := reflect.New(rv.Elem().Type())
+	done := make(chan error, 1)
+	go func() {
+		done <- unmarshal(fresh.Interface())
+	}()
+
+	timer := time.NewTimer(c.decodeTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return err
+		}
+		rv.Elem().Set(fresh.Elem())
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%w after %v", ErrDecodeTimeout, c.decodeTimeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// audit passes redacted copies of a request and response body to the
+// client's audit hook, if any.
+func (c *Client) audit(path string, reqBody, respBody []byte) {
+	if c.auditHook == nil {
+		return
+	}
+	c.auditHook(path, bytes.Clone(c.redactKey(reqBody)), bytes.Clone(c.redactKey(respBody)))
+}
+
+// redactKey returns b with every occurrence of the API key replaced by
+// "REDACTED". It returns b itself if the key does not occur.
+func (c *Client) redactKey(b []byte) []byte {
+	key := []byte(c.apiKey)
+	if len(key) == 0 || !bytes.Contains(b, key) {
+		return b
+	}
+	return bytes.ReplaceAll(b, key, []byte("REDACTED"))
+}
+
+// rawBody returns body limited to the client's maximum raw body size, and
+// whether it was truncated. Truncated bodies are copied so the full response
+// buffer can be released.
+func (c *Client) rawBody(body []byte) ([]byte, bool) {
+	if c.maxRawBodySize <= 0 || len(body) == 0 {
 		return nil, false
 	}
-	return c.cache.Get(key)
+	if len(body) > c.maxRawBodySize {
+		return bytes.Clone(body[:c.maxRawBodySize]), true
+	}
+	return body, false
 }
 
-// setCache stores a response in the cache.
+// getCached retrieves a cached response if available. Entries written with a
+// different cache schema version are evicted and treated as misses. Reads
+// through a context from withoutCacheRead always miss.
+func (c *Client) getCached(ctx context.Context, key string) ([]byte, bool) {
+	if c.cache == nil || !c.cacheConfig.Enabled || cacheReadDisabled(ctx) {
+		return nil, false
+	}
+	key = c.cacheKey(key)
+	raw, ok := c.cache.Get(key)
+	var data []byte
+	if ok {
+		if data, ok = decodeCacheValue(raw); !ok {
+			c.cache.Delete(key)
+		}
+	}
+	if ok {
+		c.cacheHits.Add(1)
+	} else {
+		c.cacheMisses.Add(1)
+	}
+	return data, ok
+}
+
+// setCache stores a response in the cache, tagged with the schema version.
 func (c *Client) setCache(key string, data []byte, ttl time.Duration) {
 	if c.cache == nil || !c.cacheConfig.Enabled {
 		return
 	}
-	c.cache.Set(key, data, ttl)
+	c.cache.Set(c.cacheKey(key), encodeCacheValue(data), ttl)
+}
+
+// cacheKey scopes key to the client's language, so that localized responses
+// are never served to a client asking for another language.
+func (c *Client) cacheKey(key string) string {
+	if c.language == "" {
+		return key
+	}
+	return "lang=" + c.language + ":" + key
 }
 
 // parseRetryAfter parses the Retry-After header value.
@@ -1,9 +1,18 @@
 package mouser
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -179,3 +188,844 @@ func TestSleepContextTimeout(t *testing.T) {
 		t.Errorf("sleep took too long: %v", elapsed)
 	}
 }
+
+// TestDoRequestWithQueryMultiValued verifies repeated query parameters are all sent
+// and that a caller-supplied apiKey cannot duplicate or override the client's key.
+func TestDoRequestWithQueryMultiValued(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q["part"]; len(got) != 3 || got[0] != "A" || got[1] != "B" || got[2] != "C" {
+			t.Errorf("expected part=[A B C], got %v", got)
+		}
+		if got := q["apiKey"]; len(got) != 1 || got[0] != "test-api-key" {
+			t.Errorf("expected exactly one apiKey=test-api-key, got %v", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	})
+
+	client := newTestClient(t, handler)
+
+	query := url.Values{}
+	query.Add("part", "A")
+	query.Add("part", "B")
+	query.Add("part", "C")
+	query.Set("apiKey", "other-key")
+
+	var resp map[string]string
+	if err := client.doRequestWithQuery(context.Background(), "GET", "/test", query, nil, &resp); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+// TestMouserErrorRawBodyOnHTTPError tests that non-2xx bodies are surfaced on MouserError.
+func TestMouserErrorRawBodyOnHTTPError(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"Message":"undocumented shape"}`))
+	})
+
+	client := newTestClient(t, handler)
+
+	err := client.doRequest(context.Background(), "GET", "/test", nil, nil)
+	var mouserErr *MouserError
+	if !errors.As(err, &mouserErr) {
+		t.Fatalf("expected *MouserError, got %T: %v", err, err)
+	}
+	if string(mouserErr.RawBody) != `{"Message":"undocumented shape"}` {
+		t.Errorf("unexpected RawBody: %q", mouserErr.RawBody)
+	}
+	if mouserErr.RawBodyTruncated {
+		t.Error("expected RawBody not to be truncated")
+	}
+}
+
+// TestRequestID tests that a context correlation ID is sent in the
+// configured header and stored on returned errors.
+func TestRequestID(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Request-ID")
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(server.URL), WithoutRetry(), WithoutCache(), WithRequestIDHeader("x-request-id"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	ctx := WithRequestID(context.Background(), "req-123")
+	err = client.doRequest(ctx, "GET", "/test", nil, nil)
+	var mouserErr *MouserError
+	if !errors.As(err, &mouserErr) {
+		t.Fatalf("expected *MouserError, got %T: %v", err, err)
+	}
+	if mouserErr.RequestID != "req-123" {
+		t.Errorf("expected RequestID req-123, got %q", mouserErr.RequestID)
+	}
+	if header != "req-123" {
+		t.Errorf("expected X-Request-ID req-123, got %q", header)
+	}
+
+	_ = client.doRequest(context.Background(), "GET", "/test", nil, nil)
+	if header != "" {
+		t.Errorf("expected no X-Request-ID without a request ID, got %q", header)
+	}
+}
+
+// TestMouserErrorRedactsEchoedAPIKey tests that an API key echoed in an
+// error response is redacted from RawBody and Details.
+func TestMouserErrorRedactsEchoedAPIKey(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"Message":"API key test-api-key is not valid"}`))
+	})
+
+	client := newTestClient(t, handler)
+
+	err := client.doRequest(context.Background(), "GET", "/test", nil, nil)
+	var mouserErr *MouserError
+	if !errors.As(err, &mouserErr) {
+		t.Fatalf("expected *MouserError, got %T: %v", err, err)
+	}
+	if want := `{"Message":"API key REDACTED is not valid"}`; string(mouserErr.RawBody) != want {
+		t.Errorf("expected RawBody %q, got %q", want, mouserErr.RawBody)
+	}
+	if strings.Contains(mouserErr.Details, "test-api-key") {
+		t.Errorf("Details leaks the API key: %q", mouserErr.Details)
+	}
+}
+
+// TestRedactAPIKey tests that only the apiKey query parameter is redacted.
+func TestRedactAPIKey(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"https://api.mouser.com/api/v2/search/keyword?apiKey=secret", "https://api.mouser.com/api/v2/search/keyword?apiKey=REDACTED"},
+		{"https://api.mouser.com/api/v1/cart?apiKey=secret&cartKey=abc", "https://api.mouser.com/api/v1/cart?apiKey=REDACTED&cartKey=abc"},
+		{"https://api.mouser.com/api/v1/cart?cartKey=abc", "https://api.mouser.com/api/v1/cart?cartKey=abc"},
+	}
+	for _, tt := range tests {
+		if got := redactAPIKey(tt.in); got != tt.want {
+			t.Errorf("redactAPIKey(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestMouserErrorRawBodyOnParseError tests that unparseable 2xx bodies are
+// surfaced on MouserError, truncated to the configured size.
+func TestMouserErrorRawBodyOnParseError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`<html>maintenance page</html>`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithMaxRawBodySize(6),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	var result map[string]interface{}
+	err = client.doRequest(context.Background(), "GET", "/test", nil, &result)
+	if !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("expected ErrInvalidResponse, got %v", err)
+	}
+	var mouserErr *MouserError
+	if !errors.As(err, &mouserErr) {
+		t.Fatalf("expected *MouserError, got %T: %v", err, err)
+	}
+	if string(mouserErr.RawBody) != "<html>" {
+		t.Errorf("expected truncated RawBody %q, got %q", "<html>", mouserErr.RawBody)
+	}
+	if !mouserErr.RawBodyTruncated {
+		t.Error("expected RawBodyTruncated to be set")
+	}
+	if !strings.Contains(err.Error(), "failed to parse response") {
+		t.Errorf("expected parse failure in message, got %q", err.Error())
+	}
+}
+
+// TestURLAuditor tests that the auditor receives the request URL without the API key.
+func TestURLAuditor(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	var methods, urls []string
+	client, err := NewClient("secret-api-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithURLAuditor(func(method, redactedURL string) {
+			methods = append(methods, method)
+			urls = append(urls, redactedURL)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	query := url.Values{}
+	query.Set("cartKey", "abc-123")
+
+	var resp map[string]string
+	if err := client.doRequestWithQuery(context.Background(), "GET", "/cart", query, nil, &resp); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(urls) != 1 {
+		t.Fatalf("expected 1 audited URL, got %d", len(urls))
+	}
+	if methods[0] != "GET" {
+		t.Errorf("expected method GET, got %s", methods[0])
+	}
+	if strings.Contains(urls[0], "secret-api-key") {
+		t.Errorf("audited URL leaks API key: %s", urls[0])
+	}
+
+	u, err := url.Parse(urls[0])
+	if err != nil {
+		t.Fatalf("audited URL does not parse: %v", err)
+	}
+	if u.Path != "/cart" {
+		t.Errorf("expected path /cart, got %s", u.Path)
+	}
+	if got := u.Query().Get("apiKey"); got != "REDACTED" {
+		t.Errorf("expected apiKey=REDACTED, got %s", got)
+	}
+	if got := u.Query().Get("cartKey"); got != "abc-123" {
+		t.Errorf("expected cartKey=abc-123, got %s", got)
+	}
+}
+
+// TestDecodeTimeout tests that a huge response fails with ErrDecodeTimeout
+// when decoding exceeds the configured limit.
+func TestDecodeTimeout(t *testing.T) {
+	var body strings.Builder
+	body.WriteString(`{"Errors":[],"SearchResults":{"NumberOfResult":200000,"Parts":[`)
+	for i := 0; i < 200000; i++ {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		fmt.Fprintf(&body, `{"MouserPartNumber":"PART-%d","Description":"Artificially large mock response"}`, i)
+	}
+	body.WriteString(`]}}`)
+	payload := []byte(body.String())
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(payload)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithDecodeTimeout(time.Microsecond),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	var resp searchResponse
+	err = client.doRequest(context.Background(), "POST", "/search/keyword", nil, &resp)
+	if !errors.Is(err, ErrDecodeTimeout) {
+		t.Fatalf("expected ErrDecodeTimeout, got %v", err)
+	}
+	if len(resp.SearchResults.Parts) != 0 {
+		t.Errorf("expected result untouched after timeout, got %d parts", len(resp.SearchResults.Parts))
+	}
+}
+
+// TestDecodeTimeoutNotExceeded tests that decoding within the limit fills the result.
+func TestDecodeTimeoutNotExceeded(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithDecodeTimeout(5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	var resp map[string]string
+	if err := client.doRequest(context.Background(), "GET", "/test", nil, &resp); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp["status"] != "ok" {
+		t.Errorf("expected status=ok, got %v", resp)
+	}
+}
+
+// TestLoggerContextFields tests that fields from ContextWithLogFields reach the logger.
+func TestLoggerContextFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/order/bad" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := NewClient("secret-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithLogger(logger),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	ctx := ContextWithLogFields(context.Background(), map[string]any{"user_id": "u-42", "bom_id": "bom-1"})
+	ctx = ContextWithLogFields(ctx, map[string]any{"bom_id": "bom-2"})
+
+	if err := client.doRequest(ctx, "GET", "/order/ok", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = client.doRequest(context.Background(), "GET", "/order/bad", nil, nil)
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 log records, got %d:\n%s", len(records), buf.String())
+	}
+
+	ok := records[0]
+	if ok["level"] != "DEBUG" || ok["path"] != "/order/ok" || ok["status"] != float64(200) {
+		t.Errorf("unexpected success record: %v", ok)
+	}
+	if ok["user_id"] != "u-42" || ok["bom_id"] != "bom-2" {
+		t.Errorf("expected context fields in record, got %v", ok)
+	}
+
+	failed := records[1]
+	if failed["level"] != "WARN" || failed["status"] != float64(404) || failed["error"] == nil {
+		t.Errorf("unexpected failure record: %v", failed)
+	}
+	if _, ok := failed["user_id"]; ok {
+		t.Errorf("expected no context fields without ContextWithLogFields, got %v", failed)
+	}
+	if strings.Contains(buf.String(), "secret-key") {
+		t.Error("API key leaked into logs")
+	}
+}
+
+// TestMaxConcurrency tests that no more than n requests are in flight at once.
+func TestMaxConcurrency(t *testing.T) {
+	const limit = 3
+
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithRateLimiter(NewRateLimiter(10000, 100000)),
+		WithMaxConcurrency(limit),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 15; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.doRequest(context.Background(), "GET", "/test", nil, nil); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p > limit {
+		t.Errorf("expected at most %d requests in flight, saw %d", limit, p)
+	} else if p < 2 {
+		t.Errorf("expected requests to run concurrently up to the limit, peak was %d", p)
+	}
+}
+
+// TestMaxConcurrencyContextCanceled tests that waiting for a slot honors the context.
+func TestMaxConcurrencyContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewClient("test-key", WithBaseURL(server.URL), WithoutRetry(), WithoutCache(), WithMaxConcurrency(1))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	go func() { _ = client.doRequest(context.Background(), "GET", "/slow", nil, nil) }()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if err := client.doRequest(ctx, "GET", "/blocked", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded while waiting for a slot, got %v", err)
+	}
+}
+
+// TestWithLanguage tests that the Accept-Language header is sent only when a
+// language is configured.
+func TestWithLanguage(t *testing.T) {
+	for _, lang := range []string{"de-DE", ""} {
+		var got string
+		var present bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, present = r.Header["Accept-Language"]
+			got = r.Header.Get("Accept-Language")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}))
+
+		client, err := NewClient("test-key",
+			WithBaseURL(server.URL),
+			WithoutRetry(),
+			WithoutCache(),
+			WithLanguage(lang),
+		)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		if err := client.doRequest(context.Background(), "GET", "/test", nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client.Close()
+		server.Close()
+
+		if lang == "" && present {
+			t.Errorf("expected no Accept-Language header, got %q", got)
+		}
+		if lang != "" && got != lang {
+			t.Errorf("expected Accept-Language=%q, got %q", lang, got)
+		}
+	}
+}
+
+// TestWithLanguageCacheKeys tests that clients sharing a cache do not serve
+// each other responses in a different language.
+func TestWithLanguageCacheKeys(t *testing.T) {
+	cache := NewMemoryCache(time.Minute)
+	defer cache.Close()
+
+	german, _ := NewClient("test-key", WithCache(cache), WithLanguage("de-DE"))
+	english, _ := NewClient("test-key", WithCache(cache))
+
+	german.setCache("details:595-NE555P", []byte(`"de"`), time.Minute)
+	if _, ok := english.getCached(context.Background(), "details:595-NE555P"); ok {
+		t.Error("expected a client without a language to miss the German entry")
+	}
+	if data, ok := german.getCached(context.Background(), "details:595-NE555P"); !ok || string(data) != `"de"` {
+		t.Errorf("expected German entry, got %q, %v", data, ok)
+	}
+	if _, ok := german.CacheAge("details:595-NE555P"); !ok {
+		t.Error("expected CacheAge to find the German entry")
+	}
+}
+
+// TestUserAgent tests the default and overridden User-Agent headers.
+func TestUserAgent(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ClientOption
+		want string
+	}{
+		{"default", nil, DefaultUserAgent},
+		{"custom", []ClientOption{WithUserAgent("bom-tool/2.3")}, "bom-tool/2.3"},
+		{"empty keeps default", []ClientOption{WithUserAgent("")}, DefaultUserAgent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("User-Agent")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			opts := append([]ClientOption{WithBaseURL(server.URL), WithoutRetry(), WithoutCache()}, tt.opts...)
+			client, err := NewClient("test-key", opts...)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			defer client.Close()
+
+			if err := client.doRequest(context.Background(), "GET", "/test", nil, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected User-Agent=%q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestWithDefaultHeaders tests that default headers are sent and only
+// replace the client's own headers when set explicitly.
+func TestWithDefaultHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	headers := http.Header{}
+	headers.Set("Proxy-Authorization", "Bearer gateway-token")
+	headers.Set("x-correlation-id", "abc-123")
+	headers.Set("Accept", "application/vnd.mouser+json")
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithDefaultHeaders(headers),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	// Changing the caller's header after construction has no effect.
+	headers.Set("X-Correlation-Id", "changed")
+
+	if err := client.doRequest(context.Background(), "POST", "/test", map[string]string{}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"Proxy-Authorization": "Bearer gateway-token",
+		"X-Correlation-Id":    "abc-123",
+		"Accept":              "application/vnd.mouser+json",
+		"Content-Type":        "application/json",
+		"User-Agent":          DefaultUserAgent,
+	}
+	for key, value := range want {
+		if got.Get(key) != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got.Get(key))
+		}
+	}
+}
+
+// TestRequestErrorRedactsAPIKey tests that transport errors, whose
+// *url.Error carries the request URL, do not leak the API key.
+func TestRequestErrorRedactsAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client, err := NewClient("secret-key-123", WithBaseURL(server.URL), WithoutRetry(), WithoutCache())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	err = client.doRequest(context.Background(), "GET", "/test", nil, nil)
+	if err == nil {
+		t.Fatal("expected an error from a closed server")
+	}
+	if strings.Contains(err.Error(), "secret-key-123") {
+		t.Errorf("error leaks the API key: %v", err)
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Fatalf("expected a *url.Error, got %T", err)
+	}
+	if !strings.Contains(urlErr.URL, "apiKey=REDACTED") {
+		t.Errorf("expected redacted URL, got %s", urlErr.URL)
+	}
+}
+
+// TestAuditHook tests that the audit hook receives redacted copies of the
+// request and response bodies.
+func TestAuditHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"OrderNumber":"ORD-001","Echo":"test-key"}`))
+	}))
+	defer server.Close()
+
+	var endpoint string
+	var reqBody, respBody []byte
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithAuditHook(func(e string, req, resp []byte) {
+			endpoint, reqBody, respBody = e, req, bytes.Clone(resp)
+			// Mutating the copies must not affect the client.
+			for i := range resp {
+				resp[i] = 'x'
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	var result struct{ OrderNumber string }
+	if err := client.doRequest(context.Background(), "POST", "/order", map[string]string{"CartKey": "abc-123"}, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.OrderNumber != "ORD-001" {
+		t.Errorf("hook mutation leaked into the result: %+v", result)
+	}
+	if endpoint != "/order" {
+		t.Errorf("expected endpoint /order, got %s", endpoint)
+	}
+	if string(reqBody) != `{"CartKey":"abc-123"}` {
+		t.Errorf("unexpected request body: %s", reqBody)
+	}
+	if string(respBody) != `{"OrderNumber":"ORD-001","Echo":"REDACTED"}` {
+		t.Errorf("expected a redacted response body, got %q", respBody)
+	}
+}
+
+// TestWithDryRun tests that mutating requests are logged instead of sent,
+// while read-only requests still reach the server.
+func TestWithDryRun(t *testing.T) {
+	var paths []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithDryRun(),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := client.Cart.InsertItems(ctx, CartItemRequestBody{CartItems: []CartItemRequest{{MouserPartNumber: "595-NE555P", Quantity: 1}}}, "US", "USD"); err != nil {
+		t.Errorf("Cart.InsertItems: %v", err)
+	}
+	if _, err := client.Order.Create(ctx, CreateOrderRequest{CartKey: "abc-123", PrimaryShipping: 1, SubmitOrder: true}); err != nil {
+		t.Errorf("Order.Create: %v", err)
+	}
+	if _, err := client.Order.QueryOptions(ctx, OrderOptionsRequest{CartKey: "abc-123"}); err != nil {
+		t.Errorf("Order.QueryOptions: %v", err)
+	}
+	if _, err := client.Search.KeywordSearch(ctx, SearchOptions{Keyword: "NE555"}); err != nil {
+		t.Errorf("Search.KeywordSearch: %v", err)
+	}
+
+	if got := strings.Join(paths, ","); got != "/order/options/query,/search/keyword" {
+		t.Errorf("expected only read-only requests to be sent, got %s", got)
+	}
+	for _, want := range []string{"dry run", "path=/cart/items/insert", "path=/order", "595-NE555P"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("expected dry run log to contain %q, got:\n%s", want, logs.String())
+		}
+	}
+}
+
+// TestStreamingDecode tests that WithStreamingDecode decodes successful
+// responses and reports malformed ones with a truncated RawBody.
+func TestStreamingDecode(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithStreamingDecode(),
+		WithMaxRawBodySize(16),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	body = `{"Errors":[],"SearchResults":{"NumberOfResult":2,"Parts":[{"MouserPartNumber":"595-NE555DR"},{"MouserPartNumber":"595-NE555P"}]}}` + "\n"
+	result, err := client.Search.KeywordSearch(context.Background(), SearchOptions{Keyword: "NE555"})
+	if err != nil {
+		t.Fatalf("KeywordSearch: %v", err)
+	}
+	if result.NumberOfResult != 2 || len(result.Parts) != 2 || result.Parts[1].MouserPartNumber != "595-NE555P" {
+		t.Errorf("unexpected streamed result %+v", result)
+	}
+
+	body = `{"SearchResults":{"NumberOfResult":"oops"}}`
+	_, err = client.Search.KeywordSearch(context.Background(), SearchOptions{Keyword: "NE555"})
+	var me *MouserError
+	if !errors.As(err, &me) {
+		t.Fatalf("expected *MouserError, got %v", err)
+	}
+	if !strings.HasPrefix(me.Message, "failed to parse response") {
+		t.Errorf("unexpected message %q", me.Message)
+	}
+	if string(me.RawBody) != body[:16] || !me.RawBodyTruncated {
+		t.Errorf("expected truncated RawBody %q, got %q (truncated=%v)", body[:16], me.RawBody, me.RawBodyTruncated)
+	}
+}
+
+// TestStreamingDecodeRedactsRawBody tests that an API key cut off at the
+// raw body limit is still redacted, and that a zero limit reports no
+// truncation.
+func TestStreamingDecodeRedactsRawBody(t *testing.T) {
+	const apiKey = "secret-api-key-1234"
+	body := `{"Echo":"` + apiKey + `","Count":"oops"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		limit         int
+		wantBody      string
+		wantTruncated bool
+	}{
+		{"key across the limit", 14, `{"Echo":"REDAC`, true},
+		{"key before the limit", 20, `{"Echo":"REDACTED","`, true},
+		{"no raw body", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(apiKey,
+				WithBaseURL(server.URL),
+				WithoutRetry(),
+				WithoutCache(),
+				WithStreamingDecode(),
+				WithMaxRawBodySize(tt.limit),
+			)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			defer client.Close()
+
+			var result struct{ Count int }
+			err = client.doRequest(context.Background(), "GET", "/echo", nil, &result)
+			var me *MouserError
+			if !errors.As(err, &me) {
+				t.Fatalf("expected *MouserError, got %v", err)
+			}
+			if strings.Contains(string(me.RawBody), "secret") {
+				t.Errorf("RawBody leaks part of the API key: %q", me.RawBody)
+			}
+			if string(me.RawBody) != tt.wantBody || me.RawBodyTruncated != tt.wantTruncated {
+				t.Errorf("got RawBody %q (truncated=%v), want %q (truncated=%v)", me.RawBody, me.RawBodyTruncated, tt.wantBody, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+// TestStreamingDecodeWithAuditHook tests that an audit hook still receives
+// the whole response body when streaming is enabled.
+func TestStreamingDecodeWithAuditHook(t *testing.T) {
+	const body = `{"status":"ok"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	var audited []byte
+	client, err := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithoutRetry(),
+		WithoutCache(),
+		WithStreamingDecode(),
+		WithAuditHook(func(_ string, _, resp []byte) { audited = resp }),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	var resp map[string]string
+	if err := client.doRequest(context.Background(), "GET", "/status", nil, &resp); err != nil {
+		t.Fatalf("doRequest: %v", err)
+	}
+	if resp["status"] != "ok" || string(audited) != body {
+		t.Errorf("expected decoded response and audited body, got %v and %q", resp, audited)
+	}
+}
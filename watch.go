@@ -0,0 +1,84 @@
+package mouser
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// watchedPartFields are the Part fields whose changes WatchPart reports.
+var watchedPartFields = map[string]bool{
+	"PriceBreaks":         true,
+	"Availability":        true,
+	"AvailabilityInStock": true,
+	"AvailabilityOnOrder": true,
+	"AvailableOnOrder":    true,
+	"FactoryStock":        true,
+}
+
+// WatchPart polls PartDetails for partNumber every interval and calls
+// onChange with the previous and current part whenever its price breaks or
+// availability change, until ctx is cancelled. The first fetch sets the
+// baseline and does not call onChange.
+//
+// Each poll bypasses the cached response and waits for a rate limit token
+// rather than failing. Transient errors skip that poll; WatchPart returns
+// early for errors that polling cannot recover from: ErrUnauthorized,
+// ErrForbidden, ErrDailyLimitExceeded, and ErrNotFound or ErrInvalidRequest
+// for a mistyped or discontinued part number. When ctx is cancelled it
+// returns ctx.Err().
+func (s *SearchService) WatchPart(ctx context.Context, partNumber string, interval time.Duration, onChange func(old, new Part)) error {
+	if interval <= 0 {
+		return errors.New("mouser: watch interval must be positive")
+	}
+
+	pollCtx := withoutCacheRead(withRateLimitWait(ctx))
+
+	var last *Part
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		part, err := s.PartDetails(pollCtx, partNumber)
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			if fatalWatchErr(err) {
+				return err
+			}
+		case last == nil:
+			last = part
+		case partChanged(*last, *part):
+			onChange(*last, *part)
+			last = part
+		default:
+			last = part
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// partChanged reports whether any watched field differs between old and new.
+func partChanged(old, new Part) bool {
+	for _, field := range old.Diff(new) {
+		if watchedPartFields[field] {
+			return true
+		}
+	}
+	return false
+}
+
+// fatalWatchErr reports whether err means further polling cannot succeed.
+func fatalWatchErr(err error) bool {
+	return errors.Is(err, ErrUnauthorized) ||
+		errors.Is(err, ErrForbidden) ||
+		errors.Is(err, ErrDailyLimitExceeded) ||
+		errors.Is(err, ErrNotFound) ||
+		errors.Is(err, ErrInvalidRequest)
+}
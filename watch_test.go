@@ -0,0 +1,124 @@
+package mouser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// watchHandler serves the part states in order, repeating the last one.
+func watchHandler(states []Part) (http.Handler, func() int) {
+	var mu sync.Mutex
+	calls := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		part := states[min(calls, len(states)-1)]
+		calls++
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(searchResponse{SearchResults: SearchResult{NumberOfResult: 1, Parts: []Part{part}}})
+	})
+	return handler, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls
+	}
+}
+
+// TestWatchPart tests that onChange fires only for price and availability
+// changes, and that polls bypass the cache.
+func TestWatchPart(t *testing.T) {
+	base := Part{
+		MouserPartNumber:    "595-NE555DR",
+		AvailabilityInStock: "100",
+		PriceBreaks:         []PriceBreak{{Quantity: 1, Price: "$1.00", Currency: "USD"}},
+	}
+	reformatted := base
+	reformatted.PriceBreaks = []PriceBreak{{Quantity: 1, Price: "1.0", Currency: "USD"}}
+	reformatted.Description = "Timer"
+	restocked := reformatted
+	restocked.AvailabilityInStock = "250"
+
+	handler, calls := watchHandler([]Part{base, reformatted, restocked})
+	client := newTestClientCached(t, handler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type change struct{ old, new Part }
+	var changes []change
+	err := client.Search.WatchPart(ctx, "595-NE555DR", 10*time.Millisecond, func(old, new Part) {
+		changes = append(changes, change{old, new})
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(changes))
+	}
+	if changes[0].old.AvailabilityInStock != "100" || changes[0].new.AvailabilityInStock != "250" {
+		t.Errorf("unexpected change %+v -> %+v", changes[0].old.AvailabilityInStock, changes[0].new.AvailabilityInStock)
+	}
+	if n := calls(); n != 3 {
+		t.Errorf("expected 3 uncached polls, got %d", n)
+	}
+}
+
+// TestWatchPartFatalError tests that WatchPart stops on an unauthorized key
+// and on part numbers Mouser does not know or rejects.
+func TestWatchPartFatalError(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply func(w http.ResponseWriter)
+		want  error
+	}{
+		{"unauthorized", func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}, ErrUnauthorized},
+		{"unknown part", func(w http.ResponseWriter) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"Errors":[],"SearchResults":{"NumberOfResult":0,"Parts":[]}}`))
+		}, ErrNotFound},
+		{"bad request", func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusBadRequest)
+		}, ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				polls++
+				tt.reply(w)
+			}))
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			err := client.Search.WatchPart(ctx, "595-NE555DR", 10*time.Millisecond, func(old, new Part) {
+				t.Error("onChange should not be called")
+			})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if polls != 1 {
+				t.Errorf("expected WatchPart to stop after 1 poll, got %d", polls)
+			}
+		})
+	}
+}
+
+// TestWatchPartInvalidInterval tests that a non-positive interval is rejected.
+func TestWatchPartInvalidInterval(t *testing.T) {
+	client := newTestClient(t, http.NotFoundHandler())
+	if err := client.Search.WatchPart(context.Background(), "X", 0, func(old, new Part) {}); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+}